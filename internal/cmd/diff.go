@@ -0,0 +1,182 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	
+	"github.com/spf13/cobra"
+	
+	"go-mapper-gen/internal/config"
+	"go-mapper-gen/internal/database"
+	"go-mapper-gen/internal/generator"
+)
+
+// diffCmd represents the diff command
+var diffCmd = &cobra.Command{
+	Use:   "diff",
+	Short: "比较表结构差异",
+	Long: `比较两份表结构，报告以下差异：
+- 新增和删除的表
+- 新增和删除的列
+- 列类型和可空性变化
+- 主键、索引和外键变化
+
+--from 指定基准快照；--to 指定目标快照，省略时读取配置的数据库。
+存在差异时以退出码 1 退出，执行出错时以退出码 2 退出，
+可用于在流水线中检查数据库是否偏离已提交的快照。`,
+	PreRun: func(cmd *cobra.Command, args []string) {
+		bindFlags(cmd, sourceFlagBindings)
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		from, _ := cmd.Flags().GetString("from")
+		to, _ := cmd.Flags().GetString("to")
+		format, _ := cmd.Flags().GetString("format")
+		runDiff(cmd.Context(), from, to, format)
+	},
+}
+
+func init() {
+	addSourceFlags(diffCmd)
+	
+	diffCmd.Flags().String("from", "", "基准表结构快照文件 (必填)")
+	diffCmd.Flags().String("to", "", "目标表结构快照文件 (省略时读取配置的数据库)")
+	diffCmd.Flags().StringP("format", "f", "text", "输出格式 (text, json)")
+	// 参数错误同样使用 diffExitError，不与存在差异的退出码 1 混淆
+	diffCmd.SetFlagErrorFunc(func(cmd *cobra.Command, err error) error {
+		diffFatalf("%v", err)
+		return err
+	})
+}
+
+// diff 命令的退出码，流水线据此区分结构偏离和执行失败
+const (
+	diffExitChanged = 1
+	diffExitError   = 2
+)
+
+// diffFatalf 输出错误并以 diffExitError 退出，避免与存在差异的退出码混淆
+func diffFatalf(format string, v ...interface{}) {
+	log.Printf(format, v...)
+	os.Exit(diffExitError)
+}
+
+func runDiff(ctx context.Context, from, to, format string) {
+	if from == "" {
+		diffFatalf("必须通过 --from 指定基准表结构快照")
+	}
+	if format != "text" && format != "json" {
+		diffFatalf("不支持的输出格式: %s, 支持的格式: text, json", format)
+	}
+	
+	// 加载配置
+	cfg, err := loadConfig()
+	if err != nil {
+		diffFatalf("加载配置失败: %v", err)
+	}
+	
+	fromTables, err := loadTables(ctx, snapshotConfig(cfg, from))
+	if err != nil {
+		diffFatalf("读取基准表结构失败: %v", err)
+	}
+	
+	target := cfg
+	if to != "" {
+		target = snapshotConfig(cfg, to)
+	}
+	toTables, err := loadTables(ctx, target)
+	if err != nil {
+		diffFatalf("读取目标表结构失败: %v", err)
+	}
+	
+	diff := database.DiffTables(fromTables, toTables)
+	if format == "json" {
+		data, err := json.MarshalIndent(diff, "", "  ")
+		if err != nil {
+			diffFatalf("序列化差异失败: %v", err)
+		}
+		fmt.Println(string(data))
+	} else {
+		fmt.Print(formatDiff(diff))
+	}
+	
+	if diff.HasChanges() {
+		os.Exit(diffExitChanged)
+	}
+}
+
+// snapshotConfig 复制配置并将表结构来源替换为快照文件，表过滤规则保持不变
+func snapshotConfig(cfg *config.Config, path string) *config.Config {
+	snapshot := *cfg
+	snapshot.Database = config.DatabaseConfig{Snapshot: path}
+	return &snapshot
+}
+
+// loadTables 按配置读取过滤后的表结构，没有匹配的表时返回空列表，
+// 便于与空库比较或生成首个迁移
+func loadTables(ctx context.Context, cfg *config.Config) ([]database.Table, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("配置验证失败: %w", err)
+	}
+	
+	gen, err := generator.New(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	defer gen.Close()
+	
+	return gen.Tables(ctx)
+}
+
+// formatDiff 生成易读的差异报告
+func formatDiff(diff database.SchemaDiff) string {
+	if !diff.HasChanges() {
+		return "表结构一致\n"
+	}
+	
+	var b strings.Builder
+	b.WriteString("表结构存在差异:\n")
+	for _, name := range diff.AddedTables {
+		fmt.Fprintf(&b, "+ 表 %s\n", name)
+	}
+	for _, name := range diff.RemovedTables {
+		fmt.Fprintf(&b, "- 表 %s\n", name)
+	}
+	for _, table := range diff.ChangedTables {
+		fmt.Fprintf(&b, "~ 表 %s\n", table.Name)
+		for _, name := range table.AddedColumns {
+			fmt.Fprintf(&b, "    + 列 %s\n", name)
+		}
+		for _, name := range table.DroppedColumns {
+			fmt.Fprintf(&b, "    - 列 %s\n", name)
+		}
+		for _, change := range table.ColumnChanges {
+			kind := "类型"
+			if change.Kind == "nullable" {
+				kind = "可空性"
+			}
+			fmt.Fprintf(&b, "    ~ 列 %s %s: %s -> %s\n", change.Column, kind, change.From, change.To)
+		}
+		if table.PrimaryKey != nil {
+			fmt.Fprintf(&b, "    ~ 主键: (%s) -> (%s)\n",
+				strings.Join(table.PrimaryKey.From, ", "), strings.Join(table.PrimaryKey.To, ", "))
+		}
+		for _, name := range table.AddedIndexes {
+			fmt.Fprintf(&b, "    + 索引 %s\n", name)
+		}
+		for _, name := range table.DroppedIndexes {
+			fmt.Fprintf(&b, "    - 索引 %s\n", name)
+		}
+		for _, name := range table.AddedForeignKeys {
+			fmt.Fprintf(&b, "    + 外键 %s\n", name)
+		}
+		for _, name := range table.DroppedForeignKeys {
+			fmt.Fprintf(&b, "    - 外键 %s\n", name)
+		}
+	}
+	
+	return b.String()
+}
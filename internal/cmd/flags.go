@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// sourceFlagBindings 表结构来源相关参数与配置项的对应关系
+var sourceFlagBindings = map[string]string{
+	"database.driver":          "driver",
+	"database.dsn":             "dsn",
+	"database.files":           "ddl-files",
+	"database.dialect":         "dialect",
+	"database.migrations":      "migrations",
+	"database.snapshot":        "schema",
+	"database.schemas":         "db-schemas",
+	"database.timeout":         "timeout",
+	"database.connect_retries": "connect-retries",
+	"tables.include":           "tables",
+	"tables.exclude":           "exclude",
+	"tables.prefix":            "prefix",
+}
+
+// addSourceFlags 添加表结构来源相关的命令行参数，generate、inspect 等命令共用
+func addSourceFlags(cmd *cobra.Command) {
+	// 数据库配置
+	cmd.Flags().StringP("driver", "d", "", "数据库驱动 (mysql, postgres, sqlite, ddl)")
+	cmd.Flags().String("dsn", "", "数据库连接字符串")
+	cmd.Flags().StringSlice("ddl-files", []string{}, "DDL 文件列表 (逗号分隔，driver 为 ddl 时使用)")
+	cmd.Flags().String("dialect", "mysql", "DDL 文件的 SQL 方言 (mysql, postgres)")
+	cmd.Flags().String("migrations", "", "迁移文件目录 (应用到内存 SQLite 后读取表结构，无需数据库连接)")
+	cmd.Flags().String("schema", "", "表结构快照文件 (inspect 命令导出的 JSON/YAML，无需数据库连接)")
+	cmd.Flags().StringSlice("db-schemas", []string{}, "要读取的 PostgreSQL schema 或 MySQL 数据库 (逗号分隔)")
+	cmd.Flags().Duration("timeout", 0, "读取表结构 (连接和查询) 的总超时，如 30s，0 表示不限制")
+	cmd.Flags().Int("connect-retries", 2, "连接数据库失败时的重试次数")
+	
+	// 表配置
+	cmd.Flags().StringSlice("tables", []string{}, "要生成的表名 (逗号分隔)")
+	cmd.Flags().StringSlice("exclude", []string{}, "要排除的表名 (逗号分隔)")
+	cmd.Flags().String("prefix", "", "表前缀")
+}
+
+// bindFlags 将命令行参数绑定到 viper 配置项。
+// 多个子命令的参数对应同一配置项，因此在命令执行前才绑定当前命令的参数。
+func bindFlags(cmd *cobra.Command, bindings map[string]string) {
+	for key, name := range bindings {
+		viper.BindPFlag(key, cmd.Flags().Lookup(name))
+	}
+}
@@ -1,11 +1,15 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"os"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
-	"github.com/spf13/viper"
 	
 	"go-mapper-gen/internal/config"
 	"go-mapper-gen/internal/generator"
@@ -20,46 +24,46 @@ var generateCmd = &cobra.Command{
 - DAO 层代码  
 - SQL 语句
 - CRUD 操作方法`,
+	PreRun: func(cmd *cobra.Command, args []string) {
+		bindFlags(cmd, sourceFlagBindings)
+		bindFlags(cmd, generateFlagBindings)
+	},
 	Run: func(cmd *cobra.Command, args []string) {
-		runGenerate()
+		runGenerate(cmd.Context())
 	},
 }
 
 func init() {
-	// 数据库配置
-	generateCmd.Flags().StringP("driver", "d", "", "数据库驱动 (mysql, postgres, sqlite)")
-	generateCmd.Flags().String("dsn", "", "数据库连接字符串")
+	addSourceFlags(generateCmd)
 	
 	// 输出配置
 	generateCmd.Flags().StringP("output", "o", "./generated", "输出目录")
 	generateCmd.Flags().StringP("package", "p", "model", "包名")
-	
-	// 表配置
-	generateCmd.Flags().StringSlice("tables", []string{}, "要生成的表名 (逗号分隔)")
-	generateCmd.Flags().StringSlice("exclude", []string{}, "要排除的表名 (逗号分隔)")
-	generateCmd.Flags().String("prefix", "", "表前缀")
+	generateCmd.Flags().Bool("split-schemas", false, "每个 schema 生成到以 schema 命名的子目录")
+	generateCmd.Flags().String("import-path", "", "输出目录的 Go 导入路径 (默认根据 go.mod 推断)")
 	
 	// 生成选项
 	generateCmd.Flags().Bool("dao", true, "生成 DAO 层代码")
 	generateCmd.Flags().Bool("sql", true, "生成 SQL 语句")
 	generateCmd.Flags().Bool("json-tag", true, "生成 JSON 标签")
 	generateCmd.Flags().Bool("example", true, "生成 Example 方法 (支持 Gobatis v1.1.0)")
-	
-	// 绑定到 viper
-	viper.BindPFlag("database.driver", generateCmd.Flags().Lookup("driver"))
-	viper.BindPFlag("database.dsn", generateCmd.Flags().Lookup("dsn"))
-	viper.BindPFlag("output.dir", generateCmd.Flags().Lookup("output"))
-	viper.BindPFlag("output.package", generateCmd.Flags().Lookup("package"))
-	viper.BindPFlag("tables.include", generateCmd.Flags().Lookup("tables"))
-	viper.BindPFlag("tables.exclude", generateCmd.Flags().Lookup("exclude"))
-	viper.BindPFlag("tables.prefix", generateCmd.Flags().Lookup("prefix"))
-	viper.BindPFlag("options.generate_dao", generateCmd.Flags().Lookup("dao"))
-	viper.BindPFlag("options.generate_sql", generateCmd.Flags().Lookup("sql"))
-	viper.BindPFlag("options.json_tag", generateCmd.Flags().Lookup("json-tag"))
-	viper.BindPFlag("options.generate_example", generateCmd.Flags().Lookup("example"))
+	generateCmd.Flags().Bool("relations", true, "根据外键生成关联字段和联表查询")
+}
+
+// generateFlagBindings generate 命令参数与配置项的对应关系
+var generateFlagBindings = map[string]string{
+	"output.dir":                 "output",
+	"output.package":             "package",
+	"output.split_schemas":       "split-schemas",
+	"output.import_path":         "import-path",
+	"options.generate_dao":       "dao",
+	"options.generate_sql":       "sql",
+	"options.json_tag":           "json-tag",
+	"options.generate_example":   "example",
+	"options.generate_relations": "relations",
 }
 
-func runGenerate() {
+func runGenerate(ctx context.Context) {
 	// 加载配置
 	cfg, err := config.LoadConfig()
 	if err != nil {
@@ -71,22 +75,110 @@ func runGenerate() {
 		log.Fatalf("配置验证失败: %v", err)
 	}
 	
+	if len(cfg.Targets) > 0 {
+		runTargets(ctx, cfg)
+		return
+	}
+	if targetName != "" {
+		log.Fatalf("配置文件中没有定义生成目标 targets")
+	}
+	
+	if _, err := generateCode(ctx, cfg); err != nil {
+		log.Fatalf("%v", err)
+	}
+	
+	fmt.Printf("代码生成完成！\n")
+}
+
+// generateCode 按配置连接数据源并生成代码，返回生成统计
+func generateCode(ctx context.Context, cfg *config.Config) (generator.Stats, error) {
 	fmt.Printf("开始生成代码...\n")
 	fmt.Printf("数据库: %s\n", cfg.Database.Driver)
 	fmt.Printf("输出目录: %s\n", cfg.Output.Dir)
 	fmt.Printf("包名: %s\n", cfg.Output.Package)
 	
 	// 创建生成器
-	gen, err := generator.New(cfg)
+	gen, err := generator.New(ctx, cfg)
 	if err != nil {
-		log.Fatalf("创建生成器失败: %v", err)
+		return generator.Stats{}, fmt.Errorf("创建生成器失败: %w", err)
 	}
 	defer gen.Close()
 	
 	// 执行生成
-	if err := gen.Generate(); err != nil {
-		log.Fatalf("生成代码失败: %v", err)
+	if err := gen.Generate(ctx); err != nil {
+		return generator.Stats{}, fmt.Errorf("生成代码失败: %w", err)
 	}
 	
-	fmt.Printf("代码生成完成！\n")
+	return gen.Stats(), nil
+}
+
+// targetResult 一个生成目标的执行结果
+type targetResult struct {
+	name     string
+	stats    generator.Stats
+	duration time.Duration
+	err      error
+}
+
+// runTargets 依次生成 --target 指定的目标或全部目标。某个目标失败时继续生成其余目标，
+// 最后输出汇总，有目标失败时以非零状态退出；ctx 被取消时不再生成剩余目标
+func runTargets(ctx context.Context, cfg *config.Config) {
+	names := cfg.TargetNames()
+	if targetName != "" {
+		if !slices.Contains(names, targetName) {
+			log.Fatalf("未找到生成目标: %s, 可用的目标: %s", targetName, strings.Join(names, ", "))
+		}
+		names = []string{targetName}
+	}
+	
+	var results []targetResult
+	for _, name := range names {
+		if ctx.Err() != nil {
+			break
+		}
+		fmt.Printf("\n==> 生成目标 %s\n", name)
+		start := time.Now()
+		// 目标缺少环境变量时记为失败，继续生成其余目标
+		var stats generator.Stats
+		targetCfg, err := cfg.Target(name)
+		if err == nil {
+			stats, err = generateCode(ctx, targetCfg)
+		}
+		results = append(results, targetResult{name: name, stats: stats, duration: time.Since(start), err: err})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "生成目标 %s 失败: %v\n", name, err)
+		}
+	}
+	
+	if !printTargetSummary(results, len(names)) {
+		os.Exit(1)
+	}
+}
+
+// printTargetSummary 输出每个生成目标的结果，全部成功时返回 true
+func printTargetSummary(results []targetResult, total int) bool {
+	width := 0
+	for _, result := range results {
+		width = max(width, len(result.name))
+	}
+	
+	fmt.Printf("\n生成汇总:\n")
+	failed := 0
+	for _, result := range results {
+		if result.err != nil {
+			failed++
+			fmt.Printf("  %-*s  失败  耗时 %s\n", width, result.name, result.duration.Round(time.Millisecond))
+			continue
+		}
+		fmt.Printf("  %-*s  成功  %d 个表，%d 个文件，耗时 %s\n", width, result.name,
+			result.stats.Tables, result.stats.Files, result.duration.Round(time.Millisecond))
+	}
+	
+	skipped := total - len(results)
+	fmt.Printf("共 %d 个目标: %d 个成功, %d 个失败", total, len(results)-failed, failed)
+	if skipped > 0 {
+		fmt.Printf(", %d 个已取消", skipped)
+	}
+	fmt.Printf("\n")
+	return failed == 0 && skipped == 0
 }
\ No newline at end of file
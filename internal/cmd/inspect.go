@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+	
+	"go-mapper-gen/internal/database"
+	"go-mapper-gen/internal/generator"
+)
+
+// inspectCmd represents the inspect command
+var inspectCmd = &cobra.Command{
+	Use:   "inspect",
+	Short: "导出表结构",
+	Long: `读取数据库 schema 并按表过滤规则导出为 JSON 或 YAML，包括：
+- 表和列信息
+- 解析后的 Go 类型
+- 主键、索引和外键
+
+导出的快照可以提交到仓库，用于评审 schema 变更或供其他工具使用。`,
+	PreRun: func(cmd *cobra.Command, args []string) {
+		bindFlags(cmd, sourceFlagBindings)
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		format, _ := cmd.Flags().GetString("format")
+		out, _ := cmd.Flags().GetString("out")
+		// 未指定格式时根据输出文件扩展名推断
+		if !cmd.Flags().Changed("format") {
+			if ext := strings.ToLower(filepath.Ext(out)); ext == ".yaml" || ext == ".yml" {
+				format = "yaml"
+			}
+		}
+		runInspect(cmd.Context(), format, out)
+	},
+}
+
+func init() {
+	addSourceFlags(inspectCmd)
+	
+	// 输出配置
+	inspectCmd.Flags().StringP("format", "f", "json", "输出格式 (json, yaml)")
+	inspectCmd.Flags().String("out", "", "输出文件路径 (默认输出到标准输出)")
+}
+
+func runInspect(ctx context.Context, format, out string) {
+	// 加载配置
+	cfg, err := loadConfig()
+	if err != nil {
+		log.Fatalf("加载配置失败: %v", err)
+	}
+	
+	// 验证配置
+	if err := cfg.Validate(); err != nil {
+		log.Fatalf("配置验证失败: %v", err)
+	}
+	
+	// 创建生成器
+	gen, err := generator.New(ctx, cfg)
+	if err != nil {
+		log.Fatalf("创建生成器失败: %v", err)
+	}
+	defer gen.Close()
+	
+	tables, err := gen.Tables(ctx)
+	if err != nil {
+		log.Fatalf("读取表结构失败: %v", err)
+	}
+	if len(tables) == 0 {
+		log.Fatalf("读取表结构失败: 没有找到匹配的表")
+	}
+	
+	data, err := marshalTables(tables, format)
+	if err != nil {
+		log.Fatalf("导出表结构失败: %v", err)
+	}
+	
+	if out == "" {
+		os.Stdout.Write(data)
+		return
+	}
+	
+	if err := os.WriteFile(out, data, 0644); err != nil {
+		log.Fatalf("写入文件 %s 失败: %v", out, err)
+	}
+	fmt.Fprintf(os.Stderr, "已导出 %d 个表到 %s\n", len(tables), out)
+}
+
+// marshalTables 将表结构序列化为指定格式
+func marshalTables(tables []database.Table, format string) ([]byte, error) {
+	switch format {
+	case "json":
+		data, err := json.MarshalIndent(tables, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		return append(data, '\n'), nil
+	case "yaml", "yml":
+		return yaml.Marshal(tables)
+	default:
+		return nil, fmt.Errorf("不支持的输出格式: %s, 支持的格式: json, yaml", format)
+	}
+}
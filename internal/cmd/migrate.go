@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	
+	"github.com/spf13/cobra"
+	
+	"go-mapper-gen/internal/generator"
+)
+
+// migrateCmd represents the migrate command
+var migrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "根据表结构差异生成迁移脚本",
+	Long: `比较两份表结构并生成迁移脚本，包括：
+- CREATE TABLE / DROP TABLE
+- ALTER TABLE ADD / DROP / MODIFY COLUMN
+- 主键变更
+- CREATE INDEX / DROP INDEX
+
+--from 指定变更前的表结构快照；--to 指定变更后的表结构快照，省略时读取配置的数据库。
+up 脚本将 --from 变更为 --to，down 脚本执行相反的操作，按编号写入迁移目录。`,
+	PreRun: func(cmd *cobra.Command, args []string) {
+		bindFlags(cmd, sourceFlagBindings)
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		from, _ := cmd.Flags().GetString("from")
+		to, _ := cmd.Flags().GetString("to")
+		dialect, _ := cmd.Flags().GetString("sql-dialect")
+		dir, _ := cmd.Flags().GetString("dir")
+		name, _ := cmd.Flags().GetString("name")
+		runMigrate(cmd.Context(), from, to, dialect, dir, name)
+	},
+}
+
+func init() {
+	addSourceFlags(migrateCmd)
+	
+	migrateCmd.Flags().String("from", "", "变更前的表结构快照文件")
+	migrateCmd.Flags().String("to", "", "变更后的表结构快照文件 (省略时读取配置的数据库)")
+	migrateCmd.Flags().String("sql-dialect", "", "迁移脚本的 SQL 方言 (mysql, postgres, sqlite，默认根据数据库驱动推断)")
+	migrateCmd.Flags().String("dir", "./migrations", "迁移文件目录")
+	migrateCmd.Flags().String("name", "schema_changes", "迁移名称")
+	migrateCmd.MarkFlagRequired("from")
+}
+
+func runMigrate(ctx context.Context, from, to, dialect, dir, name string) {
+	// 加载配置
+	cfg, err := loadConfig()
+	if err != nil {
+		log.Fatalf("加载配置失败: %v", err)
+	}
+	
+	if dialect == "" {
+		dialect = cfg.Database.SQLDialect()
+		if dialect == "" {
+			log.Fatalf("无法推断迁移脚本的 SQL 方言，请通过 --sql-dialect 指定")
+		}
+	}
+	migrationGen, err := generator.NewMigrationGenerator(dialect)
+	if err != nil {
+		log.Fatalf("创建迁移生成器失败: %v", err)
+	}
+	
+	fromTables, err := loadTables(ctx, snapshotConfig(cfg, from))
+	if err != nil {
+		log.Fatalf("读取变更前的表结构失败: %v", err)
+	}
+	
+	target := cfg
+	if to != "" {
+		target = snapshotConfig(cfg, to)
+	}
+	toTables, err := loadTables(ctx, target)
+	if err != nil {
+		log.Fatalf("读取变更后的表结构失败: %v", err)
+	}
+	
+	migration := migrationGen.Build(fromTables, toTables)
+	if migration.IsEmpty() {
+		fmt.Printf("表结构一致，无需生成迁移脚本\n")
+		return
+	}
+	
+	upPath, downPath, err := migrationGen.Write(dir, name, migration)
+	if err != nil {
+		log.Fatalf("写入迁移脚本失败: %v", err)
+	}
+	
+	fmt.Printf("生成迁移脚本: %s\n", upPath)
+	fmt.Printf("生成迁移脚本: %s\n", downPath)
+}
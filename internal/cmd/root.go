@@ -1,14 +1,23 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"os/signal"
+	"strings"
+	"syscall"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	
+	"go-mapper-gen/internal/config"
 )
 
-var cfgFile string
+var (
+	cfgFile    string
+	targetName string
+)
 
 // rootCmd represents the base command when called without any subcommands
 var rootCmd = &cobra.Command{
@@ -28,7 +37,10 @@ var rootCmd = &cobra.Command{
 // Execute adds all child commands to the root command and sets flags appropriately.
 // This is called by main.main(). It only needs to happen once to the rootCmd.
 func Execute() error {
-	return rootCmd.Execute()
+	// Ctrl-C 或 SIGTERM 时取消命令的上下文，正在进行的连接、查询和代码生成随之中止
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	return rootCmd.ExecuteContext(ctx)
 }
 
 func init() {
@@ -36,12 +48,35 @@ func init() {
 
 	// 全局配置文件标志
 	rootCmd.PersistentFlags().StringVarP(&cfgFile, "config", "c", "", "配置文件路径 (默认查找 ./generator.yaml)")
+	rootCmd.PersistentFlags().StringVar(&targetName, "target", "", "配置文件中的生成目标名称 (generate 省略时生成全部目标)")
 	
 	// 添加子命令
 	rootCmd.AddCommand(generateCmd)
+	rootCmd.AddCommand(inspectCmd)
+	rootCmd.AddCommand(diffCmd)
+	rootCmd.AddCommand(migrateCmd)
 	rootCmd.AddCommand(versionCmd)
 }
 
+// loadConfig 加载配置。配置了 targets 时返回 --target 指定的生成目标，只有一个目标时可以省略 --target；
+// inspect、diff、migrate 每次只处理一个目标
+func loadConfig() (*config.Config, error) {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return nil, err
+	}
+	
+	switch {
+	case len(cfg.Targets) == 0 && targetName == "":
+		return cfg, nil
+	case targetName != "":
+		return cfg.Target(targetName)
+	case len(cfg.Targets) == 1:
+		return cfg.Target(cfg.Targets[0].Name)
+	}
+	return nil, fmt.Errorf("配置了多个生成目标，请通过 --target 指定: %s", strings.Join(cfg.TargetNames(), ", "))
+}
+
 // initConfig reads in config file and ENV variables if set.
 func initConfig() {
 	if cfgFile != "" {
@@ -59,6 +94,9 @@ func initConfig() {
 		viper.SetConfigName("generator")
 	}
 
+	// 环境变量 GO_MAPPER_GEN_DATABASE_DSN 对应配置项 database.dsn
+	viper.SetEnvPrefix("go_mapper_gen")
+	viper.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
 	viper.AutomaticEnv() // read in environment variables that match
 
 	// If a config file is found, read it in.
@@ -3,8 +3,11 @@ package config
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/spf13/viper"
+	
+	"go-mapper-gen/internal/database"
 )
 
 // Config 生成器配置
@@ -13,18 +16,67 @@ type Config struct {
 	Output   OutputConfig   `mapstructure:"output" yaml:"output"`
 	Tables   TablesConfig   `mapstructure:"tables" yaml:"tables"`
 	Options  OptionsConfig  `mapstructure:"options" yaml:"options"`
+	Types    TypesConfig    `mapstructure:"types" yaml:"types"`
+	
+	Targets []TargetConfig `mapstructure:"targets" yaml:"targets"` // 生成目标，每个目标使用独立的数据库和输出目录，未配置的项继承以上配置
 }
 
 // DatabaseConfig 数据库配置
 type DatabaseConfig struct {
-	Driver string `mapstructure:"driver" yaml:"driver"` // mysql, postgres, sqlite
-	DSN    string `mapstructure:"dsn" yaml:"dsn"`       // 数据库连接字符串
+	Driver string `mapstructure:"driver" yaml:"driver"` // 通过 database.Register 注册的驱动，内置 mysql, postgres, sqlite, ddl
+	DSN    string `mapstructure:"dsn" yaml:"dsn"`       // 数据库连接字符串，为空时由下面的连接参数生成
+	
+	Host   string            `mapstructure:"host" yaml:"host"`     // 主机，默认 localhost
+	Port   int               `mapstructure:"port" yaml:"port"`     // 端口，为 0 时使用驱动的默认端口
+	User   string            `mapstructure:"user" yaml:"user"`     // 用户名
+	DBName string            `mapstructure:"dbname" yaml:"dbname"` // 数据库名，SQLite 为数据库文件路径
+	Params map[string]string `mapstructure:"params" yaml:"params"` // 附加的连接参数，如 charset、sslmode
+	TLS    TLSConfig         `mapstructure:"tls" yaml:"tls"`       // TLS 证书文件
+	
+	Password       string `mapstructure:"password" yaml:"password"`               // 密码，建议使用下面的来源代替明文密码
+	PasswordEnv    string `mapstructure:"password_env" yaml:"password_env"`       // 从环境变量读取密码
+	PasswordFile   string `mapstructure:"password_file" yaml:"password_file"`     // 从文件读取密码，忽略末尾换行
+	PasswordPrompt bool   `mapstructure:"password_prompt" yaml:"password_prompt"` // 在终端提示输入密码
+	
+	Files   []string `mapstructure:"files" yaml:"files"`     // DDL 文件列表，driver 为 ddl 等文件数据源时使用
+	Dialect string   `mapstructure:"dialect" yaml:"dialect"` // DDL 文件的 SQL 方言：mysql, postgres
+	
+	Migrations string `mapstructure:"migrations" yaml:"migrations"` // 迁移文件目录，设置后应用到内存 SQLite 并从中读取表结构
+	Snapshot   string `mapstructure:"snapshot" yaml:"snapshot"`     // 表结构快照文件（inspect 命令导出），设置后直接从快照读取表结构
+	
+	Schemas []string `mapstructure:"schemas" yaml:"schemas"` // 要读取的 PostgreSQL schema 或 MySQL 数据库，为空时读取 public schema 或当前数据库
+	
+	Timeout        time.Duration `mapstructure:"timeout" yaml:"timeout"`                 // 读取表结构（连接和查询）的总超时，0 表示不限制
+	ConnectRetries int           `mapstructure:"connect_retries" yaml:"connect_retries"` // 连接数据库失败时的重试次数，重试间隔按指数退避
+}
+
+// SQLDialect 返回表结构来源的 SQL 方言：迁移目录为 sqlite，文件数据源为文件的方言，其他驱动为驱动声明的方言。
+// 表结构快照无法确定方言，返回空字符串
+func (d DatabaseConfig) SQLDialect() string {
+	switch {
+	case d.Snapshot != "":
+		return ""
+	case d.Migrations != "":
+		return "sqlite"
+	}
+	factory, ok := database.Lookup(d.Driver)
+	if !ok {
+		return d.Driver
+	}
+	if source, ok := factory.(database.FileSource); ok {
+		return source.FileDialect(d.Dialect)
+	}
+	return factory.Dialect()
 }
 
 // OutputConfig 输出配置
 type OutputConfig struct {
 	Dir     string `mapstructure:"dir" yaml:"dir"`         // 输出目录
 	Package string `mapstructure:"package" yaml:"package"` // 包名
+	
+	SplitSchemas bool `mapstructure:"split_schemas" yaml:"split_schemas"` // 每个 schema 生成到输出目录下以 schema 命名的子目录
+	
+	ImportPath string `mapstructure:"import_path" yaml:"import_path"` // 输出目录的 Go 导入路径，DAO 据此导入 model 包，为空时根据 go.mod 推断
 }
 
 // TablesConfig 表配置
@@ -41,25 +93,52 @@ type OptionsConfig struct {
 	JSONTag         bool   `mapstructure:"json_tag" yaml:"json_tag"`                 // JSON 标签
 	GenerateExample bool   `mapstructure:"generate_example" yaml:"generate_example"` // 生成 Example 方法
 	NamespaceFormat string `mapstructure:"namespace_format" yaml:"namespace_format"` // XML namespace 格式模板，支持 {struct} 占位符
+	
+	GenerateRelations bool `mapstructure:"generate_relations" yaml:"generate_relations"` // 根据外键生成关联字段、结果映射和联表查询
+	
+	NullStyle string `mapstructure:"null_style" yaml:"null_style"` // 可空列的类型风格：pointer, sql_null, generic, zero_value
 }
 
-// LoadConfig 加载配置
+// nullStyles 支持的可空列类型风格
+var nullStyles = []string{"pointer", "sql_null", "generic", "zero_value"}
+
+// LoadConfig 加载配置，配置文件中的 extends、include 和环境变量在这里展开
 func LoadConfig() (*Config, error) {
 	var cfg Config
 	
 	// 设置默认值
 	setDefaults()
 	
+	// 读取配置文件，展开后的配置覆盖 viper 直接读取的内容，命令行参数仍然优先
+	if path := viper.ConfigFileUsed(); path != "" {
+		data, err := readConfigFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if err := viper.MergeConfigMap(data); err != nil {
+			return nil, fmt.Errorf("合并配置失败: %w", err)
+		}
+	}
+	
 	// 解析配置
 	if err := viper.Unmarshal(&cfg); err != nil {
 		return nil, fmt.Errorf("解析配置失败: %w", err)
 	}
 	
+	// 生成目标继承以上配置
+	targets, err := resolveTargets()
+	if err != nil {
+		return nil, err
+	}
+	cfg.Targets = targets
+	
 	return &cfg, nil
 }
 
 // setDefaults 设置默认值
 func setDefaults() {
+	viper.SetDefault("database.dialect", "mysql")
+	viper.SetDefault("database.connect_retries", 2)
 	viper.SetDefault("output.dir", "./generated")
 	viper.SetDefault("output.package", "model")
 	viper.SetDefault("options.generate_dao", true)
@@ -67,25 +146,70 @@ func setDefaults() {
 	viper.SetDefault("options.json_tag", true)
 	viper.SetDefault("options.generate_example", true)
 	viper.SetDefault("options.namespace_format", "{struct}DAO") // 默认格式：结构体名 + DAO
+	viper.SetDefault("options.generate_relations", true)
+	viper.SetDefault("options.null_style", "pointer")
 }
 
 // Validate 验证配置
 func (c *Config) Validate() error {
+	// 配置了生成目标时分别验证每个目标，顶层配置只作为目标的默认值
+	if len(c.Targets) > 0 {
+		return c.validateTargets()
+	}
+	
+	// 表结构快照或迁移目录作为表结构来源时不需要数据库连接
+	if c.Database.Snapshot != "" || c.Database.Migrations != "" {
+		return c.validateOutput()
+	}
+	
 	if c.Database.Driver == "" {
 		return fmt.Errorf("数据库驱动不能为空")
 	}
 	
-	if c.Database.DSN == "" {
-		return fmt.Errorf("数据库连接字符串不能为空")
+	if c.Database.Timeout < 0 || c.Database.ConnectRetries < 0 {
+		return fmt.Errorf("超时时间和连接重试次数不能为负数")
 	}
 	
-	// 验证驱动类型
-	supportedDrivers := []string{"mysql", "postgres", "sqlite"}
-	if !contains(supportedDrivers, c.Database.Driver) {
+	// 验证驱动类型，驱动名、连接字符串格式由已注册的驱动提供
+	factory, ok := database.Lookup(c.Database.Driver)
+	if !ok {
 		return fmt.Errorf("不支持的数据库驱动: %s, 支持的驱动: %s", 
-			c.Database.Driver, strings.Join(supportedDrivers, ", "))
+			c.Database.Driver, strings.Join(database.Drivers(), ", "))
 	}
 	
+	// 文件数据源只需要文件列表和方言，不校验连接配置
+	if source, ok := factory.(database.FileSource); ok {
+		if err := source.ValidateFiles(c.Database.Files, c.Database.Dialect); err != nil {
+			return err
+		}
+		return c.validateOutput()
+	}
+	
+	// 配置 dsn 时校验连接字符串，否则校验用于生成连接字符串的连接参数
+	if c.Database.DSN != "" {
+		if c.Database.hasConnectionParams() || len(c.Database.passwordSources()) > 0 {
+			return fmt.Errorf("dsn 不能与 host、port、user、dbname 和密码等连接参数同时配置")
+		}
+		if err := factory.ValidateDSN(c.Database.DSN); err != nil {
+			return fmt.Errorf("数据库连接字符串无效: %w", database.RedactError(err, c.Database.DSN))
+		}
+	} else {
+		if !c.Database.hasConnectionParams() {
+			return fmt.Errorf("数据库连接字符串不能为空")
+		}
+		if _, ok := factory.(database.DSNBuilder); !ok {
+			return fmt.Errorf("驱动 %s 不支持由连接参数生成连接字符串，请配置 dsn", c.Database.Driver)
+		}
+		if err := c.Database.validateConnection(); err != nil {
+			return err
+		}
+	}
+	
+	return c.validateOutput()
+}
+
+// validateOutput 验证输出、生成选项和类型映射配置
+func (c *Config) validateOutput() error {
 	if c.Output.Dir == "" {
 		return fmt.Errorf("输出目录不能为空")
 	}
@@ -94,7 +218,12 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("包名不能为空")
 	}
 	
-	return nil
+	if c.Options.NullStyle != "" && !contains(nullStyles, c.Options.NullStyle) {
+		return fmt.Errorf("不支持的可空类型风格: %s, 支持的风格: %s",
+			c.Options.NullStyle, strings.Join(nullStyles, ", "))
+	}
+	
+	return c.Types.Validate()
 }
 
 // contains 检查切片是否包含指定元素
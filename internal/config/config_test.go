@@ -1,11 +1,15 @@
 package config
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/spf13/viper"
+	
+	"go-mapper-gen/internal/database"
 )
 
 func TestLoadConfig(t *testing.T) {
@@ -166,6 +170,24 @@ func TestConfigValidate(t *testing.T) {
 			wantErr: true,
 			errMsg:  "包名不能为空",
 		},
+		{
+			name: "不支持的可空类型风格",
+			config: Config{
+				Database: DatabaseConfig{
+					Driver: "sqlite",
+					DSN:    "test.db",
+				},
+				Output: OutputConfig{
+					Dir:     "./output",
+					Package: "model",
+				},
+				Options: OptionsConfig{
+					NullStyle: "nullable",
+				},
+			},
+			wantErr: true,
+			errMsg:  "不支持的可空类型风格: nullable, 支持的风格: pointer, sql_null, generic, zero_value",
+		},
 	}
 	
 	for _, tt := range tests {
@@ -209,4 +231,151 @@ func TestSetDefaults(t *testing.T) {
 	if !viper.GetBool("options.json_tag") {
 		t.Error("期望默认生成JSON标签为true")
 	}
-}
\ No newline at end of file
+}
+// registeredDriver 测试用驱动，方言为 postgres
+type registeredDriver struct{}
+
+func (registeredDriver) Open(dsn string, schemas []string) (database.Database, error) {
+	return database.NewSnapshot(dsn), nil
+}
+
+func (registeredDriver) ValidateDSN(dsn string) error {
+	if !strings.HasPrefix(dsn, "catalog://") {
+		return fmt.Errorf("连接字符串必须以 catalog:// 开头")
+	}
+	return nil
+}
+
+func (registeredDriver) Dialect() string {
+	return "postgres"
+}
+
+func (registeredDriver) GoType(dbType string, nullable bool) string {
+	return "string"
+}
+
+func TestConfigRegisteredDriver(t *testing.T) {
+	database.Register("catalog-config-test", registeredDriver{})
+	
+	cfg := Config{
+		Database: DatabaseConfig{Driver: "catalog-config-test", DSN: "catalog://prod"},
+		Output:   OutputConfig{Dir: "./output", Package: "model"},
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("期望注册的驱动验证通过，实际为 %v", err)
+	}
+	if dialect := cfg.Database.SQLDialect(); dialect != "postgres" {
+		t.Errorf("期望使用驱动声明的方言 postgres，实际为 %s", dialect)
+	}
+	
+	cfg.Database.DSN = "prod"
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "数据库连接字符串无效") {
+		t.Errorf("期望连接字符串由驱动校验，实际为 %v", err)
+	}
+}
+
+func TestConfigFileSourceDriver(t *testing.T) {
+	cfg := Config{
+		Database: DatabaseConfig{Driver: "ddl", Files: []string{"schema.sql"}},
+		Output:   OutputConfig{Dir: "./output", Package: "model"},
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("期望 ddl 驱动不需要连接配置，实际为 %v", err)
+	}
+	if dialect := cfg.Database.SQLDialect(); dialect != "mysql" {
+		t.Errorf("期望 DDL 文件默认使用 mysql 方言，实际为 %s", dialect)
+	}
+	cfg.Database.Dialect = "postgres"
+	if dialect := cfg.Database.SQLDialect(); dialect != "postgres" {
+		t.Errorf("期望使用配置的 DDL 方言 postgres，实际为 %s", dialect)
+	}
+	
+	cfg.Database.Dialect = "oracle"
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "不支持的 DDL 方言") {
+		t.Errorf("期望 DDL 方言由驱动校验，实际为 %v", err)
+	}
+	cfg.Database.Dialect = ""
+	cfg.Database.Files = nil
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "DDL 文件列表不能为空") {
+		t.Errorf("期望 DDL 文件列表由驱动校验，实际为 %v", err)
+	}
+}
+
+func TestConnectionDSN(t *testing.T) {
+	base := DatabaseConfig{Driver: "mysql", Host: "db.example.com", User: "root", DBName: "test_db"}
+	
+	t.Setenv("GMG_TEST_PASSWORD", "from-env")
+	passwordFile := filepath.Join(t.TempDir(), "password")
+	if err := os.WriteFile(passwordFile, []byte("from-file\n"), 0600); err != nil {
+		t.Fatalf("写入密码文件失败: %v", err)
+	}
+	defer func(prompt func(string) (string, error)) { promptPassword = prompt }(promptPassword)
+	promptPassword = func(prompt string) (string, error) {
+		return "from-prompt", nil
+	}
+	
+	tests := []struct {
+		name     string
+		modify   func(d *DatabaseConfig)
+		expected string
+	}{
+		{"环境变量", func(d *DatabaseConfig) { d.PasswordEnv = "GMG_TEST_PASSWORD" }, "root:from-env@tcp(db.example.com:3306)/test_db"},
+		{"密码文件", func(d *DatabaseConfig) { d.PasswordFile = passwordFile }, "root:from-file@tcp(db.example.com:3306)/test_db"},
+		{"终端输入", func(d *DatabaseConfig) { d.PasswordPrompt = true }, "root:from-prompt@tcp(db.example.com:3306)/test_db"},
+		{"直接使用 dsn", func(d *DatabaseConfig) { *d = DatabaseConfig{Driver: "mysql", DSN: "root@/db"} }, "root@/db"},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := base
+			tt.modify(&d)
+			dsn, err := d.ConnectionDSN()
+			if err != nil {
+				t.Fatalf("生成连接字符串失败: %v", err)
+			}
+			if dsn != tt.expected {
+				t.Errorf("期望 %s，实际为 %s", tt.expected, dsn)
+			}
+		})
+	}
+	
+	d := base
+	d.PasswordEnv = "GMG_TEST_PASSWORD_UNSET"
+	if _, err := d.ConnectionDSN(); err == nil {
+		t.Error("期望环境变量未设置时返回错误")
+	}
+}
+
+func TestConfigValidateConnection(t *testing.T) {
+	tests := []struct {
+		name    string
+		db      DatabaseConfig
+		wantErr string
+	}{
+		{"结构化连接参数", DatabaseConfig{Driver: "postgres", Host: "db", DBName: "app", PasswordEnv: "PGPASSWORD"}, ""},
+		{"缺少连接参数", DatabaseConfig{Driver: "postgres"}, "数据库连接字符串不能为空"},
+		{"同时配置 dsn", DatabaseConfig{Driver: "mysql", DSN: "root@/db", Host: "db"}, "不能与"},
+		{"多个密码来源", DatabaseConfig{Driver: "mysql", Host: "db", PasswordEnv: "PW", PasswordPrompt: true}, "只能配置一种密码来源"},
+		{"证书缺少私钥", DatabaseConfig{Driver: "mysql", Host: "db", TLS: TLSConfig{Cert: "client.pem"}}, "必须同时配置"},
+		{"连接字符串中的密码", DatabaseConfig{Driver: "postgres", DSN: "postgres://app:secret@db:port/app"}, "数据库连接字符串无效"},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := Config{Database: tt.db, Output: OutputConfig{Dir: "./output", Package: "model"}}
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("期望验证通过，实际为 %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("期望错误包含 %s，实际为 %v", tt.wantErr, err)
+			}
+			if strings.Contains(err.Error(), "secret") {
+				t.Errorf("期望错误信息隐藏密码，实际为 %v", err)
+			}
+		})
+	}
+}
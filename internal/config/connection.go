@@ -0,0 +1,121 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+
+	"go-mapper-gen/internal/database"
+)
+
+// TLSConfig 数据库连接使用的 TLS 证书文件，均为 PEM 格式
+type TLSConfig struct {
+	CA   string `mapstructure:"ca" yaml:"ca"`     // CA 证书，设置后校验服务端证书
+	Cert string `mapstructure:"cert" yaml:"cert"` // 客户端证书
+	Key  string `mapstructure:"key" yaml:"key"`   // 客户端私钥
+}
+
+// hasConnectionParams 判断是否使用结构化连接参数代替 dsn
+func (d DatabaseConfig) hasConnectionParams() bool {
+	return d.Host != "" || d.Port != 0 || d.User != "" || d.DBName != ""
+}
+
+// passwordSources 返回配置的密码来源
+func (d DatabaseConfig) passwordSources() []string {
+	var sources []string
+	if d.Password != "" {
+		sources = append(sources, "password")
+	}
+	if d.PasswordEnv != "" {
+		sources = append(sources, "password_env")
+	}
+	if d.PasswordFile != "" {
+		sources = append(sources, "password_file")
+	}
+	if d.PasswordPrompt {
+		sources = append(sources, "password_prompt")
+	}
+	return sources
+}
+
+// validateConnection 验证结构化连接参数
+func (d DatabaseConfig) validateConnection() error {
+	if d.Port < 0 || d.Port > 65535 {
+		return fmt.Errorf("无效的端口: %d", d.Port)
+	}
+	if sources := d.passwordSources(); len(sources) > 1 {
+		return fmt.Errorf("只能配置一种密码来源: %s", strings.Join(sources, ", "))
+	}
+	if (d.TLS.Cert == "") != (d.TLS.Key == "") {
+		return fmt.Errorf("TLS 客户端证书和私钥必须同时配置")
+	}
+	return nil
+}
+
+// ConnectionDSN 返回连接字符串：配置了 dsn 时直接使用，否则读取密码并由驱动根据结构化连接参数生成。
+// 配置了 password_prompt 时会在终端提示输入密码
+func (d DatabaseConfig) ConnectionDSN() (string, error) {
+	if d.DSN != "" {
+		return d.DSN, nil
+	}
+	
+	password, err := d.readPassword()
+	if err != nil {
+		return "", err
+	}
+	
+	return database.BuildDSN(d.Driver, database.ConnectionParams{
+		Host:     d.Host,
+		Port:     d.Port,
+		User:     d.User,
+		Password: password,
+		Database: d.DBName,
+		Params:   d.Params,
+		TLS: database.TLSFiles{
+			CA:   d.TLS.CA,
+			Cert: d.TLS.Cert,
+			Key:  d.TLS.Key,
+		},
+	})
+}
+
+// readPassword 从配置的来源读取密码，未配置时返回空字符串
+func (d DatabaseConfig) readPassword() (string, error) {
+	switch {
+	case d.PasswordEnv != "":
+		password, ok := os.LookupEnv(d.PasswordEnv)
+		if !ok {
+			return "", fmt.Errorf("密码环境变量 %s 未设置", d.PasswordEnv)
+		}
+		return password, nil
+	case d.PasswordFile != "":
+		data, err := os.ReadFile(d.PasswordFile)
+		if err != nil {
+			return "", fmt.Errorf("读取密码文件失败: %w", err)
+		}
+		// 忽略文件末尾的换行
+		return strings.TrimRight(string(data), "\r\n"), nil
+	case d.PasswordPrompt:
+		return promptPassword(fmt.Sprintf("请输入数据库用户 %s 的密码: ", d.User))
+	}
+	return d.Password, nil
+}
+
+// promptPassword 在终端提示输入密码，输入内容不回显；测试中可以替换
+var promptPassword = func(prompt string) (string, error) {
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		return "", fmt.Errorf("标准输入不是终端，无法提示输入密码")
+	}
+	
+	// 提示输出到标准错误，inspect 命令的标准输出用于导出表结构
+	fmt.Fprint(os.Stderr, prompt)
+	password, err := term.ReadPassword(fd)
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return "", fmt.Errorf("读取密码失败: %w", err)
+	}
+	return string(password), nil
+}
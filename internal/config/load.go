@@ -0,0 +1,217 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// readConfigFile 读取配置文件：先合并 extends 指定的基础配置，再依次合并 include 指定的配置片段，
+// 最后合并文件自身的配置，合并完成后替换字符串中的环境变量。
+// 生成目标中的环境变量在解析目标时替换，未选中的目标缺少环境变量不影响其他目标
+func readConfigFile(path string) (map[string]interface{}, error) {
+	data, err := loadConfigFile(path, nil)
+	if err != nil {
+		return nil, err
+	}
+	
+	targets, hasTargets := data["targets"]
+	delete(data, "targets")
+	if err := interpolate(data, ""); err != nil {
+		return nil, err
+	}
+	if hasTargets {
+		data["targets"] = targets
+	}
+	return data, nil
+}
+
+// loadConfigFile 读取配置文件并递归合并 extends 和 include，chain 为正在读取的文件，用于检测循环引用
+func loadConfigFile(path string, chain []string) (map[string]interface{}, error) {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, fmt.Errorf("解析配置文件路径失败: %w", err)
+	}
+	for _, loading := range chain {
+		if loading == absPath {
+			return nil, fmt.Errorf("配置文件循环引用: %s", strings.Join(append(chain, absPath), " -> "))
+		}
+	}
+	chain = append(chain, absPath)
+	
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("读取配置文件失败: %w", err)
+	}
+	var data map[string]interface{}
+	if err := yaml.Unmarshal(content, &data); err != nil {
+		return nil, fmt.Errorf("解析配置文件 %s 失败: %w", path, err)
+	}
+	
+	// extends 和 include 中的路径相对于当前配置文件所在目录
+	dir := filepath.Dir(path)
+	merged := make(map[string]interface{})
+	
+	if extends, ok := data["extends"]; ok {
+		base, ok := extends.(string)
+		if !ok {
+			return nil, fmt.Errorf("配置文件 %s 的 extends 必须是文件路径", path)
+		}
+		baseData, err := loadReferencedFile(dir, base, chain)
+		if err != nil {
+			return nil, err
+		}
+		mergeMaps(merged, baseData)
+	}
+	
+	if include, ok := data["include"]; ok {
+		fragments, err := stringList(include)
+		if err != nil {
+			return nil, fmt.Errorf("配置文件 %s 的 include 必须是文件路径或路径列表", path)
+		}
+		for _, fragment := range fragments {
+			fragmentData, err := loadReferencedFile(dir, fragment, chain)
+			if err != nil {
+				return nil, err
+			}
+			mergeMaps(merged, fragmentData)
+		}
+	}
+	
+	delete(data, "extends")
+	delete(data, "include")
+	mergeMaps(merged, data)
+	return merged, nil
+}
+
+// loadReferencedFile 读取 extends 或 include 引用的配置文件，路径可以使用环境变量
+func loadReferencedFile(dir, ref string, chain []string) (map[string]interface{}, error) {
+	path, err := expandEnv(ref)
+	if err != nil {
+		return nil, err
+	}
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(dir, path)
+	}
+	return loadConfigFile(path, chain)
+}
+
+// stringList 将字符串或字符串列表转换为切片
+func stringList(value interface{}) ([]string, error) {
+	switch v := value.(type) {
+	case string:
+		return []string{v}, nil
+	case []interface{}:
+		list := make([]string, 0, len(v))
+		for _, item := range v {
+			s, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("不是字符串: %v", item)
+			}
+			list = append(list, s)
+		}
+		return list, nil
+	}
+	return nil, fmt.Errorf("不是字符串或字符串列表: %v", value)
+}
+
+// mergeMaps 将 src 合并到 dst：两边都是映射时递归合并，否则 src 的值覆盖 dst，列表整体替换
+func mergeMaps(dst, src map[string]interface{}) {
+	for key, value := range src {
+		srcMap, srcOK := value.(map[string]interface{})
+		dstMap, dstOK := dst[key].(map[string]interface{})
+		if srcOK && dstOK {
+			mergeMaps(dstMap, srcMap)
+			continue
+		}
+		// 复制一份，避免之后的合并和环境变量替换修改被引用的配置
+		dst[key] = copyValue(value)
+	}
+}
+
+// copyValue 深拷贝映射和列表，其他值原样返回
+func copyValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		copied := make(map[string]interface{}, len(v))
+		mergeMaps(copied, v)
+		return copied
+	case []interface{}:
+		copied := make([]interface{}, len(v))
+		for i, item := range v {
+			copied[i] = copyValue(item)
+		}
+		return copied
+	}
+	return value
+}
+
+// envPattern 匹配 ${VAR}、${VAR:-default} 和转义的 $${
+var envPattern = regexp.MustCompile(`\$\$\{|\$\{([A-Za-z_][A-Za-z0-9_]*)(:-([^}]*))?\}`)
+
+// interpolate 原地替换映射或列表中所有字符串的环境变量，path 为当前配置项路径，用于错误信息
+func interpolate(value interface{}, path string) error {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			itemPath := key
+			if path != "" {
+				itemPath = path + "." + key
+			}
+			if s, ok := item.(string); ok {
+				expanded, err := expandEnv(s)
+				if err != nil {
+					return fmt.Errorf("配置项 %s: %w", itemPath, err)
+				}
+				v[key] = expanded
+			} else if err := interpolate(item, itemPath); err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		for i, item := range v {
+			itemPath := fmt.Sprintf("%s[%d]", path, i)
+			if s, ok := item.(string); ok {
+				expanded, err := expandEnv(s)
+				if err != nil {
+					return fmt.Errorf("配置项 %s: %w", itemPath, err)
+				}
+				v[i] = expanded
+			} else if err := interpolate(item, itemPath); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// expandEnv 替换字符串中的 ${VAR} 和 ${VAR:-default}：变量未设置或为空时使用默认值，
+// 未设置且没有默认值时返回错误；$${ 表示字面量 ${
+func expandEnv(s string) (string, error) {
+	var missing []string
+	expanded := envPattern.ReplaceAllStringFunc(s, func(match string) string {
+		if match == "$${" {
+			return "${"
+		}
+		groups := envPattern.FindStringSubmatch(match)
+		value, ok := os.LookupEnv(groups[1])
+		if groups[2] != "" {
+			if value == "" {
+				return groups[3]
+			}
+			return value
+		}
+		if !ok {
+			missing = append(missing, groups[1])
+		}
+		return value
+	})
+	if len(missing) > 0 {
+		return "", fmt.Errorf("环境变量未设置: %s", strings.Join(missing, ", "))
+	}
+	return expanded, nil
+}
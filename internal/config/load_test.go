@@ -0,0 +1,162 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+// writeConfigFiles 在临时目录中写入配置文件，返回目录路径
+func writeConfigFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("创建目录失败: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("写入配置文件失败: %v", err)
+		}
+	}
+	return dir
+}
+
+func TestLoadConfigExtends(t *testing.T) {
+	dir := writeConfigFiles(t, map[string]string{
+		"base/generator.yaml": `
+database:
+  driver: postgres
+  host: ${DB_HOST:-localhost}
+  port: ${DB_PORT:-5432}
+  password_env: PGPASSWORD
+output:
+  package: model
+options:
+  null_style: sql_null
+  json_tag: false
+tables:
+  exclude: ["schema_migrations"]
+`,
+		"base/tables.yaml": `
+tables:
+  exclude: ["schema_migrations", "audit_*"]
+`,
+		"billing/generator.yaml": `
+extends: ../base/generator.yaml
+include: [../base/tables.yaml, types.yaml]
+database:
+  dbname: billing
+output:
+  dir: ./gen/${SERVICE}
+`,
+		"billing/types.yaml": `
+types:
+  mappings:
+    - db_type: numeric
+      go_type: github.com/shopspring/decimal.Decimal
+`,
+	})
+	
+	t.Setenv("DB_HOST", "db.internal")
+	t.Setenv("SERVICE", "billing")
+	viper.Reset()
+	viper.SetConfigFile(filepath.Join(dir, "billing", "generator.yaml"))
+	
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("加载配置失败: %v", err)
+	}
+	
+	if cfg.Database.Driver != "postgres" || cfg.Database.DBName != "billing" {
+		t.Errorf("期望合并基础配置和覆盖配置的数据库设置，实际为 %+v", cfg.Database)
+	}
+	if cfg.Database.Host != "db.internal" || cfg.Database.Port != 5432 {
+		t.Errorf("期望替换环境变量和默认值，实际为 host=%s port=%d", cfg.Database.Host, cfg.Database.Port)
+	}
+	if cfg.Output.Dir != "./gen/billing" || cfg.Output.Package != "model" {
+		t.Errorf("期望输出配置为 ./gen/billing 和 model，实际为 %s 和 %s", cfg.Output.Dir, cfg.Output.Package)
+	}
+	if cfg.Options.NullStyle != "sql_null" || cfg.Options.JSONTag {
+		t.Errorf("期望继承基础配置的生成选项，实际为 %+v", cfg.Options)
+	}
+	if !cfg.Options.GenerateDAO {
+		t.Error("期望未配置的选项使用默认值")
+	}
+	if len(cfg.Tables.Exclude) != 2 || cfg.Tables.Exclude[1] != "audit_*" {
+		t.Errorf("期望配置片段整体替换排除列表，实际为 %v", cfg.Tables.Exclude)
+	}
+	if len(cfg.Types.Mappings) != 1 {
+		t.Errorf("期望合并类型映射片段，实际为 %v", cfg.Types.Mappings)
+	}
+}
+
+func TestLoadConfigErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		files   map[string]string
+		wantErr string
+	}{
+		{
+			name:    "环境变量未设置",
+			files:   map[string]string{"generator.yaml": "database:\n  dsn: ${GMG_TEST_UNSET_DSN}\n"},
+			wantErr: "配置项 database.dsn: 环境变量未设置: GMG_TEST_UNSET_DSN",
+		},
+		{
+			name: "循环引用",
+			files: map[string]string{
+				"generator.yaml": "extends: base.yaml\n",
+				"base.yaml":      "extends: generator.yaml\n",
+			},
+			wantErr: "配置文件循环引用",
+		},
+		{
+			name:    "片段不存在",
+			files:   map[string]string{"generator.yaml": "include: missing.yaml\n"},
+			wantErr: "读取配置文件失败",
+		},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := writeConfigFiles(t, tt.files)
+			viper.Reset()
+			viper.SetConfigFile(filepath.Join(dir, "generator.yaml"))
+			
+			_, err := LoadConfig()
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("期望错误包含 %s，实际为 %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestExpandEnv(t *testing.T) {
+	t.Setenv("GMG_TEST_USER", "app")
+	t.Setenv("GMG_TEST_EMPTY", "")
+	
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"${GMG_TEST_USER}@localhost", "app@localhost"},
+		{"${GMG_TEST_EMPTY:-fallback}", "fallback"},
+		{"${GMG_TEST_UNSET:-}", ""},
+		{"$${GMG_TEST_USER}", "${GMG_TEST_USER}"},
+		{"$HOME", "$HOME"},
+	}
+	
+	for _, tt := range tests {
+		expanded, err := expandEnv(tt.input)
+		if err != nil {
+			t.Errorf("expandEnv(%q) 返回错误: %v", tt.input, err)
+			continue
+		}
+		if expanded != tt.expected {
+			t.Errorf("expandEnv(%q) 期望 %q，实际为 %q", tt.input, tt.expected, expanded)
+		}
+	}
+}
@@ -0,0 +1,167 @@
+package config
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// TargetConfig 生成目标，拥有独立的数据库、表、输出、选项和类型映射配置，未配置的项继承顶层配置
+type TargetConfig struct {
+	Name     string         `mapstructure:"name" yaml:"name"`
+	Database DatabaseConfig `mapstructure:"database" yaml:"database"`
+	Output   OutputConfig   `mapstructure:"output" yaml:"output"`
+	Tables   TablesConfig   `mapstructure:"tables" yaml:"tables"`
+	Options  OptionsConfig  `mapstructure:"options" yaml:"options"`
+	Types    TypesConfig    `mapstructure:"types" yaml:"types"`
+	
+	err error // 替换目标中的环境变量失败的错误，选中该目标时返回
+}
+
+// Config 返回生成目标对应的独立配置
+func (t TargetConfig) Config() *Config {
+	return &Config{
+		Database: t.Database,
+		Output:   t.Output,
+		Tables:   t.Tables,
+		Options:  t.Options,
+		Types:    t.Types,
+	}
+}
+
+// TargetNames 返回生成目标的名称，保持配置中的顺序
+func (c *Config) TargetNames() []string {
+	names := make([]string, 0, len(c.Targets))
+	for _, target := range c.Targets {
+		names = append(names, target.Name)
+	}
+	return names
+}
+
+// Target 返回指定名称的生成目标的配置
+func (c *Config) Target(name string) (*Config, error) {
+	for _, target := range c.Targets {
+		if target.Name == name {
+			if target.err != nil {
+				return nil, target.err
+			}
+			return target.Config(), nil
+		}
+	}
+	if len(c.Targets) == 0 {
+		return nil, fmt.Errorf("配置文件中没有定义生成目标 targets")
+	}
+	return nil, fmt.Errorf("未找到生成目标: %s, 可用的目标: %s", name, strings.Join(c.TargetNames(), ", "))
+}
+
+// validateTargets 验证生成目标的名称和每个目标的配置
+func (c *Config) validateTargets() error {
+	seen := make(map[string]bool)
+	for i, target := range c.Targets {
+		if target.Name == "" {
+			return fmt.Errorf("第 %d 个生成目标的名称不能为空", i+1)
+		}
+		if seen[target.Name] {
+			return fmt.Errorf("生成目标名称重复: %s", target.Name)
+		}
+		seen[target.Name] = true
+		
+		// 缺少环境变量的目标在选中时才报错，不影响其他目标
+		if target.err != nil {
+			continue
+		}
+		if err := target.Config().Validate(); err != nil {
+			return fmt.Errorf("生成目标 %s: %w", target.Name, err)
+		}
+	}
+	return nil
+}
+
+// resolveTargets 将每个生成目标与顶层配置合并后解析。顶层配置包含默认值、配置文件、环境变量和命令行参数，
+// 目标中配置的项覆盖顶层配置：映射逐项合并，列表整体替换，目标指定了新的连接方式时不继承顶层的连接配置。目标中的环境变量在这里替换，
+// 替换失败的错误记录在目标中，选中该目标时返回
+func resolveTargets() ([]TargetConfig, error) {
+	raw := viper.Get("targets")
+	if raw == nil {
+		return nil, nil
+	}
+	items, ok := raw.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("targets 必须是生成目标列表")
+	}
+	
+	base := viper.AllSettings()
+	delete(base, "targets")
+	
+	targets := make([]TargetConfig, 0, len(items))
+	for i, item := range items {
+		overlay, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("第 %d 个生成目标必须是映射", i+1)
+		}
+		
+		overlay = copyValue(overlay).(map[string]interface{})
+		interpolateErr := interpolate(overlay, fmt.Sprintf("targets[%d]", i))
+		
+		merged := make(map[string]interface{})
+		mergeMaps(merged, base)
+		if database, ok := overlay["database"].(map[string]interface{}); ok {
+			dropInheritedConnection(merged, database)
+		}
+		mergeMaps(merged, overlay)
+		
+		v := viper.New()
+		if err := v.MergeConfigMap(merged); err != nil {
+			return nil, fmt.Errorf("合并第 %d 个生成目标的配置失败: %w", i+1, err)
+		}
+		var target TargetConfig
+		if err := v.Unmarshal(&target); err != nil {
+			return nil, fmt.Errorf("解析第 %d 个生成目标的配置失败: %w", i+1, err)
+		}
+		target.err = interpolateErr
+		targets = append(targets, target)
+	}
+	return targets, nil
+}
+
+// 数据库配置中指定连接方式的配置项
+var (
+	connectionParamKeys = []string{"host", "port", "user", "dbname", "params", "tls",
+		"password", "password_env", "password_file", "password_prompt"}
+	schemaSourceKeys = []string{"snapshot", "migrations", "files"}
+)
+
+// dropInheritedConnection 目标指定了新的连接方式时，删除 merged 中从顶层继承的其他连接方式，避免 dsn 与 host 等连接参数混用：
+// 配置 driver 时不继承任何连接配置和 schemas，配置 dsn 时不继承连接参数，配置 host、port、user、dbname 时不继承 dsn。
+// 超时、重试次数等其他配置仍然继承
+func dropInheritedConnection(merged, database map[string]interface{}) {
+	inherited, ok := merged["database"].(map[string]interface{})
+	if !ok {
+		return
+	}
+	
+	var dropped []string
+	switch {
+	case hasAnyKey(database, "driver"):
+		dropped = slices.Concat([]string{"dsn", "schemas"}, connectionParamKeys, schemaSourceKeys)
+	case hasAnyKey(database, "dsn"):
+		dropped = slices.Concat(connectionParamKeys, schemaSourceKeys)
+	case hasAnyKey(database, "host", "port", "user", "dbname"):
+		dropped = append([]string{"dsn"}, schemaSourceKeys...)
+	}
+	for _, key := range dropped {
+		delete(inherited, key)
+	}
+}
+
+// hasAnyKey 判断映射是否包含任意一个键
+func hasAnyKey(m map[string]interface{}, keys ...string) bool {
+	for _, key := range keys {
+		if _, ok := m[key]; ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,230 @@
+package config
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestLoadConfigTargets(t *testing.T) {
+	dir := writeConfigFiles(t, map[string]string{"generator.yaml": `
+database:
+  driver: mysql
+  timeout: 30s
+output:
+  package: model
+options:
+  generate_sql: false
+tables:
+  exclude: ["schema_migrations"]
+targets:
+  - name: billing
+    database:
+      driver: postgres
+      dsn: postgres://app@localhost/billing
+    output:
+      dir: ./billing/gen
+  - name: users
+    database:
+      dsn: root@tcp(localhost:3306)/users
+    output:
+      dir: ./users/gen
+      package: users
+    options:
+      generate_sql: true
+    tables:
+      exclude: ["sessions"]
+`})
+	
+	viper.Reset()
+	viper.SetConfigFile(filepath.Join(dir, "generator.yaml"))
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("加载配置失败: %v", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("期望验证通过，实际为 %v", err)
+	}
+	if names := strings.Join(cfg.TargetNames(), ","); names != "billing,users" {
+		t.Fatalf("期望生成目标为 billing,users，实际为 %s", names)
+	}
+	
+	billing, err := cfg.Target("billing")
+	if err != nil {
+		t.Fatalf("获取生成目标失败: %v", err)
+	}
+	if billing.Database.Driver != "postgres" || billing.Database.Timeout.String() != "30s" {
+		t.Errorf("期望覆盖驱动并继承超时，实际为 %+v", billing.Database)
+	}
+	if billing.Output.Dir != "./billing/gen" || billing.Output.Package != "model" {
+		t.Errorf("期望继承包名，实际为 %+v", billing.Output)
+	}
+	if billing.Options.GenerateSQL || !billing.Options.GenerateDAO {
+		t.Errorf("期望继承顶层选项和默认值，实际为 %+v", billing.Options)
+	}
+	if len(billing.Targets) != 0 {
+		t.Error("期望生成目标的配置不包含 targets")
+	}
+	
+	users, err := cfg.Target("users")
+	if err != nil {
+		t.Fatalf("获取生成目标失败: %v", err)
+	}
+	if users.Database.Driver != "mysql" || users.Output.Package != "users" || !users.Options.GenerateSQL {
+		t.Errorf("期望 users 使用自己的包名和选项，实际为 %+v %+v", users.Output, users.Options)
+	}
+	if len(users.Tables.Exclude) != 1 || users.Tables.Exclude[0] != "sessions" {
+		t.Errorf("期望目标的列表整体替换顶层配置，实际为 %v", users.Tables.Exclude)
+	}
+	
+	if _, err := cfg.Target("orders"); err == nil || !strings.Contains(err.Error(), "billing, users") {
+		t.Errorf("期望未找到目标时列出可用的目标，实际为 %v", err)
+	}
+}
+
+func TestLoadConfigTargetConnection(t *testing.T) {
+	dir := writeConfigFiles(t, map[string]string{"generator.yaml": `
+database:
+  driver: mysql
+  host: db.internal
+  user: app
+  dbname: shop
+  password_env: SHOP_DB_PASSWORD
+  schemas: ["shop"]
+  timeout: 30s
+output:
+  package: model
+targets:
+  - name: legacy
+    database:
+      dsn: root@tcp(localhost:3306)/legacy
+    output:
+      dir: ./legacy/gen
+  - name: reports
+    database:
+      dbname: reports
+    output:
+      dir: ./reports/gen
+  - name: local
+    database:
+      driver: sqlite
+      dbname: ./local.db
+    output:
+      dir: ./local/gen
+`})
+	
+	viper.Reset()
+	viper.SetConfigFile(filepath.Join(dir, "generator.yaml"))
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("加载配置失败: %v", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("期望验证通过，实际为 %v", err)
+	}
+	
+	// 配置 dsn 的目标不继承结构化连接参数，仍然继承驱动、schemas 和超时
+	legacy, err := cfg.Target("legacy")
+	if err != nil {
+		t.Fatalf("获取生成目标失败: %v", err)
+	}
+	if legacy.Database.Host != "" || legacy.Database.User != "" || legacy.Database.DBName != "" || legacy.Database.PasswordEnv != "" {
+		t.Errorf("期望 dsn 目标不继承连接参数，实际为 %+v", legacy.Database)
+	}
+	if legacy.Database.Driver != "mysql" || legacy.Database.Timeout.String() != "30s" || len(legacy.Database.Schemas) != 1 {
+		t.Errorf("期望 dsn 目标继承驱动、schemas 和超时，实际为 %+v", legacy.Database)
+	}
+	
+	// 只修改数据库名的目标继承其他连接参数
+	reports, err := cfg.Target("reports")
+	if err != nil {
+		t.Fatalf("获取生成目标失败: %v", err)
+	}
+	if reports.Database.Host != "db.internal" || reports.Database.PasswordEnv != "SHOP_DB_PASSWORD" || reports.Database.DBName != "reports" {
+		t.Errorf("期望继承连接参数并覆盖数据库名，实际为 %+v", reports.Database)
+	}
+	
+	// 更换驱动的目标不继承任何连接配置
+	local, err := cfg.Target("local")
+	if err != nil {
+		t.Fatalf("获取生成目标失败: %v", err)
+	}
+	if local.Database.Host != "" || local.Database.PasswordEnv != "" || len(local.Database.Schemas) != 0 || local.Database.DBName != "./local.db" {
+		t.Errorf("期望更换驱动的目标不继承连接配置，实际为 %+v", local.Database)
+	}
+}
+
+func TestLoadConfigTargetEnv(t *testing.T) {
+	t.Setenv("GMG_TEST_USERS_DSN", "root@tcp(localhost:3306)/users")
+	dir := writeConfigFiles(t, map[string]string{"generator.yaml": `
+database:
+  driver: mysql
+output:
+  package: model
+targets:
+  - name: users
+    database:
+      dsn: ${GMG_TEST_USERS_DSN}
+    output:
+      dir: ./users/gen
+  - name: billing
+    database:
+      dsn: ${GMG_TEST_BILLING_DSN}
+    output:
+      dir: ./billing/gen
+`})
+	
+	// 未选中的目标缺少环境变量不影响加载、验证和其他目标
+	viper.Reset()
+	viper.SetConfigFile(filepath.Join(dir, "generator.yaml"))
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("加载配置失败: %v", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("期望验证通过，实际为 %v", err)
+	}
+	users, err := cfg.Target("users")
+	if err != nil {
+		t.Fatalf("获取生成目标失败: %v", err)
+	}
+	if users.Database.DSN != "root@tcp(localhost:3306)/users" {
+		t.Errorf("期望替换目标中的环境变量，实际为 %s", users.Database.DSN)
+	}
+	
+	_, err = cfg.Target("billing")
+	if err == nil || !strings.Contains(err.Error(), "targets[1].database.dsn") || !strings.Contains(err.Error(), "GMG_TEST_BILLING_DSN") {
+		t.Errorf("期望选中缺少环境变量的目标时返回错误，实际为 %v", err)
+	}
+}
+
+func TestConfigValidateTargets(t *testing.T) {
+	target := func(name, dsn string) TargetConfig {
+		return TargetConfig{
+			Name:     name,
+			Database: DatabaseConfig{Driver: "sqlite", DSN: dsn},
+			Output:   OutputConfig{Dir: "./" + name, Package: "model"},
+		}
+	}
+	
+	tests := []struct {
+		name    string
+		targets []TargetConfig
+		wantErr string
+	}{
+		{"名称为空", []TargetConfig{target("", "a.db")}, "名称不能为空"},
+		{"名称重复", []TargetConfig{target("a", "a.db"), target("a", "b.db")}, "生成目标名称重复: a"},
+		{"目标配置无效", []TargetConfig{target("a", "a.db"), target("b", "")}, "生成目标 b: 数据库连接字符串不能为空"},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := Config{Targets: tt.targets}
+			if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("期望错误包含 %s，实际为 %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// TypesConfig 类型映射配置，覆盖内置的数据库类型到 Go 类型的转换
+type TypesConfig struct {
+	Mappings []TypeMapping       `mapstructure:"mappings" yaml:"mappings"` // 按数据库类型的全局映射
+	Columns  []ColumnTypeMapping `mapstructure:"columns" yaml:"columns"`   // 按表和列的映射，优先于全局映射
+}
+
+// TypeMapping 数据库类型到 Go 类型的映射
+type TypeMapping struct {
+	Driver   string `mapstructure:"driver" yaml:"driver"`     // 适用的数据库：mysql, postgres, sqlite，为空时适用于所有数据库
+	DBType   string `mapstructure:"db_type" yaml:"db_type"`   // 数据库类型，不区分大小写，可以是基础类型 (decimal) 或完整类型 (tinyint(1))
+	GoType   string `mapstructure:"go_type" yaml:"go_type"`   // Go 类型，可以带导入路径，如 github.com/shopspring/decimal.Decimal
+	Nullable string `mapstructure:"nullable" yaml:"nullable"` // 可空列使用的 Go 类型，为空时使用 GoType 的指针
+	Import   string `mapstructure:"import" yaml:"import"`     // 导入路径，GoType 带导入路径时可省略
+}
+
+// ColumnTypeMapping 指定列的 Go 类型
+type ColumnTypeMapping struct {
+	Table    string `mapstructure:"table" yaml:"table"`       // 表名，为空或 * 时匹配所有表
+	Column   string `mapstructure:"column" yaml:"column"`     // 列名
+	GoType   string `mapstructure:"go_type" yaml:"go_type"`   // Go 类型，可以带导入路径
+	Nullable string `mapstructure:"nullable" yaml:"nullable"` // 可空列使用的 Go 类型，为空时使用 GoType 的指针
+	Import   string `mapstructure:"import" yaml:"import"`     // 导入路径，GoType 带导入路径时可省略
+}
+
+// GoTypeFor 返回映射后的 Go 类型和导入路径
+func (m TypeMapping) GoTypeFor(nullable bool) (string, string) {
+	return resolveGoType(m.GoType, m.Nullable, m.Import, nullable)
+}
+
+// GoTypeFor 返回映射后的 Go 类型和导入路径
+func (m ColumnTypeMapping) GoTypeFor(nullable bool) (string, string) {
+	return resolveGoType(m.GoType, m.Nullable, m.Import, nullable)
+}
+
+// MatchTable 判断映射是否适用于指定表
+func (m ColumnTypeMapping) MatchTable(table string) bool {
+	return m.Table == "" || m.Table == "*" || m.Table == table
+}
+
+// MatchType 判断映射是否适用于指定数据库和列类型，dialect 为空（如表结构快照）时不按数据库过滤
+func (m TypeMapping) MatchType(dialect string, columnTypes ...string) bool {
+	if m.Driver != "" && dialect != "" && m.Driver != dialect {
+		return false
+	}
+	for _, columnType := range columnTypes {
+		if columnType != "" && strings.EqualFold(m.DBType, columnType) {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate 验证类型映射配置
+func (c TypesConfig) Validate() error {
+	for i, mapping := range c.Mappings {
+		if mapping.DBType == "" || mapping.GoType == "" {
+			return fmt.Errorf("第 %d 个类型映射的 db_type 和 go_type 不能为空", i+1)
+		}
+		if mapping.Driver != "" && !contains([]string{"mysql", "postgres", "sqlite"}, mapping.Driver) {
+			return fmt.Errorf("第 %d 个类型映射的数据库不支持: %s, 支持的数据库: mysql, postgres, sqlite", i+1, mapping.Driver)
+		}
+	}
+	for i, mapping := range c.Columns {
+		if mapping.Column == "" || mapping.GoType == "" {
+			return fmt.Errorf("第 %d 个列类型映射的 column 和 go_type 不能为空", i+1)
+		}
+	}
+	return nil
+}
+
+// resolveGoType 计算 Go 类型和导入路径。类型带导入路径时拆分为包名限定的类型，
+// 如 github.com/shopspring/decimal.Decimal 拆分为 decimal.Decimal 和 github.com/shopspring/decimal
+func resolveGoType(goType, nullableType, importPath string, nullable bool) (string, string) {
+	if nullable {
+		if nullableType != "" {
+			goType = nullableType
+		} else if !strings.HasPrefix(goType, "*") {
+			goType = "*" + goType
+		}
+	}
+	
+	// 指针、切片等前缀保持不变
+	base := strings.TrimLeft(goType, "*[]")
+	prefix := goType[:len(goType)-len(base)]
+	slash := strings.LastIndex(base, "/")
+	dot := strings.LastIndex(base, ".")
+	if slash < 0 || dot < slash {
+		return goType, importPath
+	}
+	
+	path := base[:dot]
+	if importPath == "" {
+		importPath = path
+	}
+	return prefix + packageName(path) + base[dot:], importPath
+}
+
+// packageName 根据导入路径推断包名，跳过 /v2 等主版本后缀和 gopkg.in 的 .v4 等版本后缀
+func packageName(path string) string {
+	parts := strings.Split(path, "/")
+	name := parts[len(parts)-1]
+	if len(parts) > 1 && len(name) > 1 && name[0] == 'v' && strings.Trim(name[1:], "0123456789") == "" {
+		name = parts[len(parts)-2]
+	}
+	if i := strings.Index(name, "."); i > 0 {
+		name = name[:i]
+	}
+	return name
+}
@@ -0,0 +1,66 @@
+package config
+
+import (
+	"testing"
+)
+
+func TestTypeMappingGoTypeFor(t *testing.T) {
+	tests := []struct {
+		mapping        TypeMapping
+		nullable       bool
+		expectedType   string
+		expectedImport string
+	}{
+		{TypeMapping{GoType: "github.com/shopspring/decimal.Decimal"}, false, "decimal.Decimal", "github.com/shopspring/decimal"},
+		{TypeMapping{GoType: "github.com/shopspring/decimal.Decimal", Nullable: "github.com/shopspring/decimal.NullDecimal"}, true, "decimal.NullDecimal", "github.com/shopspring/decimal"},
+		{TypeMapping{GoType: "github.com/google/uuid.UUID"}, true, "*uuid.UUID", "github.com/google/uuid"},
+		{TypeMapping{GoType: "github.com/jackc/pgx/v5/pgtype.Numeric"}, false, "pgtype.Numeric", "github.com/jackc/pgx/v5/pgtype"},
+		{TypeMapping{GoType: "gopkg.in/guregu/null.v4.String"}, false, "null.String", "gopkg.in/guregu/null.v4"},
+		{TypeMapping{GoType: "[]github.com/google/uuid.UUID"}, false, "[]uuid.UUID", "github.com/google/uuid"},
+		{TypeMapping{GoType: "null.String", Import: "gopkg.in/guregu/null.v4"}, false, "null.String", "gopkg.in/guregu/null.v4"},
+		{TypeMapping{GoType: "string", Nullable: "database/sql.NullString"}, true, "sql.NullString", "database/sql"},
+		{TypeMapping{GoType: "int64"}, true, "*int64", ""},
+	}
+	
+	for _, tt := range tests {
+		goType, importPath := tt.mapping.GoTypeFor(tt.nullable)
+		if goType != tt.expectedType || importPath != tt.expectedImport {
+			t.Errorf("%+v (nullable=%v): 期望 %s (%s)，实际为 %s (%s)",
+				tt.mapping, tt.nullable, tt.expectedType, tt.expectedImport, goType, importPath)
+		}
+	}
+}
+
+func TestTypeMappingMatchType(t *testing.T) {
+	mapping := TypeMapping{Driver: "mysql", DBType: "TINYINT(1)", GoType: "bool"}
+	if !mapping.MatchType("mysql", "tinyint(1)", "tinyint") {
+		t.Error("期望按完整类型匹配，不区分大小写")
+	}
+	if mapping.MatchType("postgres", "tinyint(1)") {
+		t.Error("期望不匹配其他数据库")
+	}
+	if !mapping.MatchType("", "tinyint(1)") {
+		t.Error("期望无法确定数据库时不按数据库过滤")
+	}
+}
+
+func TestTypesConfigValidate(t *testing.T) {
+	invalid := []TypesConfig{
+		{Mappings: []TypeMapping{{DBType: "decimal"}}},
+		{Mappings: []TypeMapping{{Driver: "oracle", DBType: "number", GoType: "int64"}}},
+		{Columns: []ColumnTypeMapping{{Table: "users", GoType: "string"}}},
+	}
+	for _, types := range invalid {
+		if err := types.Validate(); err == nil {
+			t.Errorf("期望 %+v 验证失败", types)
+		}
+	}
+	
+	valid := TypesConfig{
+		Mappings: []TypeMapping{{Driver: "postgres", DBType: "uuid", GoType: "github.com/google/uuid.UUID"}},
+		Columns:  []ColumnTypeMapping{{Column: "id", GoType: "int64"}},
+	}
+	if err := valid.Validate(); err != nil {
+		t.Errorf("期望验证通过，实际为 %v", err)
+	}
+}
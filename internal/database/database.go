@@ -1,10 +1,12 @@
 package database
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
+	"strconv"
 	"strings"
-
+	
 	_ "github.com/go-sql-driver/mysql"
 	_ "github.com/lib/pq"
 	_ "github.com/mattn/go-sqlite3"
@@ -12,59 +14,174 @@ import (
 
 // Column 表示数据库列信息
 type Column struct {
-	Name         string `json:"name"`          // 列名
-	Type         string `json:"type"`          // 数据库类型
-	GoType       string `json:"go_type"`       // Go 类型
-	Nullable     bool   `json:"nullable"`      // 是否可空
-	IsPrimaryKey bool   `json:"is_primary_key"` // 是否主键
-	IsAutoIncr   bool   `json:"is_auto_incr"`  // 是否自增
-	DefaultValue string `json:"default_value"` // 默认值
-	Comment      string `json:"comment"`       // 注释
+	Name         string   `json:"name" yaml:"name"`                                   // 列名
+	Type         string   `json:"type" yaml:"type"`                                   // 数据库类型
+	GoType       string   `json:"go_type" yaml:"go_type"`                             // Go 类型
+	Nullable     bool     `json:"nullable" yaml:"nullable"`                           // 是否可空
+	IsPrimaryKey bool     `json:"is_primary_key" yaml:"is_primary_key"`               // 是否主键
+	IsAutoIncr   bool     `json:"is_auto_incr" yaml:"is_auto_incr"`                   // 是否自增
+	DefaultValue string   `json:"default_value" yaml:"default_value"`                 // 默认值
+	Comment      string   `json:"comment" yaml:"comment"`                             // 注释
+	FullType     string   `json:"full_type,omitempty" yaml:"full_type,omitempty"`     // 完整列类型，如 int(10) unsigned、enum('a','b')
+	EnumValues   []string `json:"enum_values,omitempty" yaml:"enum_values,omitempty"` // ENUM/SET 列的可选值
+	EnumName     string   `json:"enum_name,omitempty" yaml:"enum_name,omitempty"`     // PostgreSQL 枚举类型名，多个列可共用同一枚举类型
+	GoImport     string   `json:"-" yaml:"-"`                                         // Go 类型需要的导入路径，由类型映射配置设置
+	
+	Length        int64  `json:"length,omitempty" yaml:"length,omitempty"`                 // 字符和二进制类型的最大长度
+	Precision     int    `json:"precision,omitempty" yaml:"precision,omitempty"`           // 定点数和浮点数的精度（十进制位数）
+	Scale         int    `json:"scale,omitempty" yaml:"scale,omitempty"`                   // 定点数和浮点数的小数位数
+	Charset       string `json:"charset,omitempty" yaml:"charset,omitempty"`               // 字符集
+	Collation     string `json:"collation,omitempty" yaml:"collation,omitempty"`           // 排序规则
+	Generated     string `json:"generated,omitempty" yaml:"generated,omitempty"`           // 生成列的存储方式：VIRTUAL、STORED，普通列为空
+	GeneratedExpr string `json:"generated_expr,omitempty" yaml:"generated_expr,omitempty"` // 生成列的表达式
+	Identity      string `json:"identity,omitempty" yaml:"identity,omitempty"`             // PostgreSQL 标识列的生成方式：ALWAYS、BY DEFAULT，普通列为空
+	Sequence      string `json:"sequence,omitempty" yaml:"sequence,omitempty"`             // PostgreSQL 自增列使用的序列名
+}
+
+// Index 表示索引或唯一约束信息
+type Index struct {
+	Name      string   `json:"name" yaml:"name"`             // 索引名
+	Columns   []string `json:"columns" yaml:"columns"`       // 索引列，按索引中的顺序排列
+	IsUnique  bool     `json:"is_unique" yaml:"is_unique"`   // 是否唯一索引/唯一约束
+	IsPrimary bool     `json:"is_primary" yaml:"is_primary"` // 是否主键索引
+}
+
+// ForeignKey 表示外键信息
+type ForeignKey struct {
+	Name       string   `json:"name" yaml:"name"`               // 外键约束名
+	Columns    []string `json:"columns" yaml:"columns"`         // 本表列
+	RefTable   string   `json:"ref_table" yaml:"ref_table"`     // 引用表
+	RefColumns []string `json:"ref_columns" yaml:"ref_columns"` // 引用表列，与 Columns 一一对应
 }
 
 // Table 表示数据库表信息
 type Table struct {
-	Name    string   `json:"name"`    // 表名
-	Comment string   `json:"comment"` // 表注释
-	Columns []Column `json:"columns"` // 列信息
+	Schema      string       `json:"schema,omitempty" yaml:"schema,omitempty"`             // 所属 schema（MySQL 为数据库名），只在配置了 database.schemas 时设置
+	Name        string       `json:"name" yaml:"name"`                                     // 表名
+	Kind        string       `json:"kind,omitempty" yaml:"kind,omitempty"`                 // 表类型：view、materialized_view，普通表为空
+	Comment     string       `json:"comment" yaml:"comment"`                               // 表注释
+	Columns     []Column     `json:"columns" yaml:"columns"`                               // 列信息
+	Indexes     []Index      `json:"indexes,omitempty" yaml:"indexes,omitempty"`           // 索引信息（不包含表达式索引和部分索引）
+	ForeignKeys []ForeignKey `json:"foreign_keys,omitempty" yaml:"foreign_keys,omitempty"` // 外键信息
+	
+	Strict       bool `json:"strict,omitempty" yaml:"strict,omitempty"`               // SQLite STRICT 表
+	WithoutRowid bool `json:"without_rowid,omitempty" yaml:"without_rowid,omitempty"` // SQLite WITHOUT ROWID 表
+}
+
+// 表类型，普通表的 Kind 为空
+const (
+	KindView             = "view"              // 视图
+	KindMaterializedView = "materialized_view" // PostgreSQL 物化视图
+)
+
+// IsView 判断是否为视图或物化视图，视图只生成只读的 DAO
+func (t Table) IsView() bool {
+	return t.Kind == KindView || t.Kind == KindMaterializedView
+}
+
+// QualifiedName 返回带 schema 的表名，如 billing.invoices，未设置 schema 时返回表名
+func (t Table) QualifiedName() string {
+	if t.Schema == "" {
+		return t.Name
+	}
+	return t.Schema + "." + t.Name
+}
+
+// SizedType 返回带长度或精度的列类型，如 character varying(100)、numeric(10,2)。
+// 类型本身已带括号时原样返回，整数等类型的固有精度不会写入类型
+func (c Column) SizedType() string {
+	if strings.Contains(c.Type, "(") {
+		return c.Type
+	}
+	switch typ := strings.ToLower(c.Type); {
+	case c.Length > 0 && sizedTypes[typ]:
+		return fmt.Sprintf("%s(%d)", c.Type, c.Length)
+	case c.Precision > 0 && (typ == "numeric" || typ == "decimal"):
+		if c.Scale > 0 {
+			return fmt.Sprintf("%s(%d,%d)", c.Type, c.Precision, c.Scale)
+		}
+		return fmt.Sprintf("%s(%d)", c.Type, c.Precision)
+	}
+	return c.Type
 }
 
-// Database 数据库接口
+// splitTableName 将 schema.table 形式的表名拆分为 schema 和表名，不带 schema 时 schema 为空
+func splitTableName(name string) (string, string) {
+	if i := strings.Index(name, "."); i >= 0 {
+		return name[:i], name[i+1:]
+	}
+	return "", name
+}
+
+// Database 数据库接口，表名可以是 schema.table 形式
 type Database interface {
-	Connect() error
+	Connect(ctx context.Context) error
 	Close() error
-	GetTables() ([]Table, error)
-	GetTableColumns(tableName string) ([]Column, error)
-}
-
-// NewDatabase 创建数据库实例
-func NewDatabase(driver, dsn string) (Database, error) {
-	switch driver {
-	case "mysql":
-		return &MySQL{DSN: dsn}, nil
-	case "postgres":
-		return &PostgreSQL{DSN: dsn}, nil
-	case "sqlite":
-		return &SQLite{DSN: dsn}, nil
-	default:
+	GetTables(ctx context.Context, filter TableFilter) ([]Table, error)
+	GetTableColumns(ctx context.Context, tableName string) ([]Column, error)
+	GetTableIndexes(ctx context.Context, tableName string) ([]Index, error)
+	GetTableForeignKeys(ctx context.Context, tableName string) ([]ForeignKey, error)
+}
+
+// appendIndexColumn 将索引列追加到对应索引中，保持索引首次出现的顺序
+func appendIndexColumn(indexes []Index, name, column string, unique, primary bool) []Index {
+	for i := range indexes {
+		if indexes[i].Name == name {
+			indexes[i].Columns = append(indexes[i].Columns, column)
+			return indexes
+		}
+	}
+	return append(indexes, Index{
+		Name:      name,
+		Columns:   []string{column},
+		IsUnique:  unique,
+		IsPrimary: primary,
+	})
+}
+
+// NewDatabase 使用已注册的驱动创建数据库实例，schemas 为要读取的 PostgreSQL schema 或 MySQL 数据库，为空时读取 public schema 或当前数据库
+func NewDatabase(driver, dsn string, schemas []string) (Database, error) {
+	factory, ok := Lookup(driver)
+	if !ok {
 		return nil, fmt.Errorf("不支持的数据库驱动: %s", driver)
 	}
+	return factory.Open(dsn, schemas)
+}
+
+// appendForeignKeyColumn 将外键列追加到对应外键中，保持外键首次出现的顺序
+func appendForeignKeyColumn(foreignKeys []ForeignKey, name, column, refTable, refColumn string) []ForeignKey {
+	for i := range foreignKeys {
+		if foreignKeys[i].Name == name {
+			foreignKeys[i].Columns = append(foreignKeys[i].Columns, column)
+			foreignKeys[i].RefColumns = append(foreignKeys[i].RefColumns, refColumn)
+			return foreignKeys
+		}
+	}
+	return append(foreignKeys, ForeignKey{
+		Name:       name,
+		Columns:    []string{column},
+		RefTable:   refTable,
+		RefColumns: []string{refColumn},
+	})
 }
 
 // MySQL 实现
 type MySQL struct {
-	DSN string
-	db  *sql.DB
+	DSN     string
+	Schemas []string // 要读取的数据库，为空时读取当前数据库
+	db      *sql.DB
 }
 
-func (m *MySQL) Connect() error {
+func (m *MySQL) Connect(ctx context.Context) error {
 	db, err := sql.Open("mysql", m.DSN)
 	if err != nil {
-		return fmt.Errorf("连接 MySQL 失败: %w", err)
+		return fmt.Errorf("连接 MySQL 失败: %w", RedactError(err, m.DSN))
 	}
 	
-	if err := db.Ping(); err != nil {
-		return fmt.Errorf("ping MySQL 失败: %w", err)
+	// 连接失败时关闭连接池，避免重试时泄漏
+	if err := db.PingContext(ctx); err != nil {
+		db.Close()
+		return fmt.Errorf("ping MySQL 失败: %w", RedactError(err, m.DSN))
 	}
 	
 	m.db = db
@@ -78,21 +195,25 @@ func (m *MySQL) Close() error {
 	return nil
 }
 
-func (m *MySQL) GetTables() ([]Table, error) {
+// GetTables 先读取表清单并按 filter 筛选，再为选中的表批量查询列、索引和外键，避免逐表查询
+func (m *MySQL) GetTables(ctx context.Context, filter TableFilter) ([]Table, error) {
+	schemaFilter, args := m.schemaCondition()
 	query := `
 		SELECT 
+			TABLE_SCHEMA,
 			TABLE_NAME,
+			TABLE_TYPE,
 			TABLE_COMMENT
 		FROM 
 			INFORMATION_SCHEMA.TABLES 
 		WHERE 
-			TABLE_SCHEMA = DATABASE()
-			AND TABLE_TYPE = 'BASE TABLE'
+			` + schemaFilter + `
+			AND TABLE_TYPE IN ('BASE TABLE', 'VIEW')
 		ORDER BY 
-			TABLE_NAME
+			TABLE_SCHEMA, TABLE_NAME
 	`
 	
-	rows, err := m.db.Query(query)
+	rows, err := m.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("查询表信息失败: %w", err)
 	}
@@ -101,62 +222,194 @@ func (m *MySQL) GetTables() ([]Table, error) {
 	var tables []Table
 	for rows.Next() {
 		var table Table
-		if err := rows.Scan(&table.Name, &table.Comment); err != nil {
+		var schema, tableType string
+		if err := rows.Scan(&schema, &table.Name, &tableType, &table.Comment); err != nil {
 			return nil, fmt.Errorf("扫描表信息失败: %w", err)
 		}
 		
-		// 获取列信息
-		columns, err := m.GetTableColumns(table.Name)
-		if err != nil {
-			return nil, fmt.Errorf("获取表 %s 的列信息失败: %w", table.Name, err)
+		// 视图的 TABLE_COMMENT 固定为 VIEW，不作为注释
+		if tableType == "VIEW" {
+			table.Kind = KindView
+			table.Comment = ""
 		}
-		table.Columns = columns
 		
-		tables = append(tables, table)
+		// 只在读取多个数据库时记录 schema，保持单库时的表名不变
+		if len(m.Schemas) > 0 {
+			table.Schema = schema
+		}
+		
+		if filter.Match(table) {
+			tables = append(tables, table)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("读取表信息失败: %w", err)
+	}
+	rows.Close()
+	
+	if len(tables) == 0 {
+		return nil, nil
+	}
+	
+	// 按表名批量查询，不同数据库中的同名表按分组键区分
+	seen := make(map[string]bool)
+	for _, table := range tables {
+		if !seen[table.Name] {
+			seen[table.Name] = true
+			args = append(args, table.Name)
+		}
+	}
+	condition := schemaFilter + " AND TABLE_NAME IN (" + placeholders(len(seen)) + ")"
+	
+	columns, err := m.columns(ctx, condition, args)
+	if err != nil {
+		return nil, fmt.Errorf("获取列信息失败: %w", err)
+	}
+	indexes, err := m.indexes(ctx, condition, args)
+	if err != nil {
+		return nil, fmt.Errorf("获取索引信息失败: %w", err)
+	}
+	foreignKeys, err := m.foreignKeys(ctx, condition, args)
+	if err != nil {
+		return nil, fmt.Errorf("获取外键信息失败: %w", err)
+	}
+	
+	for i := range tables {
+		key := tables[i].QualifiedName()
+		tables[i].Columns = columns[key]
+		tables[i].Indexes = indexes[key]
+		tables[i].ForeignKeys = foreignKeys[key]
 	}
 	
 	return tables, nil
 }
 
-func (m *MySQL) GetTableColumns(tableName string) ([]Column, error) {
+func (m *MySQL) GetTableColumns(ctx context.Context, tableName string) ([]Column, error) {
+	condition, args := tableCondition(tableName)
+	columns, err := m.columns(ctx, condition, args)
+	if err != nil {
+		return nil, err
+	}
+	// 条件只匹配一张表，结果中最多只有一组
+	for _, tableColumns := range columns {
+		return tableColumns, nil
+	}
+	return nil, nil
+}
+
+func (m *MySQL) GetTableIndexes(ctx context.Context, tableName string) ([]Index, error) {
+	condition, args := tableCondition(tableName)
+	indexes, err := m.indexes(ctx, condition, args)
+	if err != nil {
+		return nil, err
+	}
+	for _, tableIndexes := range indexes {
+		return tableIndexes, nil
+	}
+	return nil, nil
+}
+
+func (m *MySQL) GetTableForeignKeys(ctx context.Context, tableName string) ([]ForeignKey, error) {
+	condition, args := tableCondition(tableName)
+	foreignKeys, err := m.foreignKeys(ctx, condition, args)
+	if err != nil {
+		return nil, err
+	}
+	for _, tableForeignKeys := range foreignKeys {
+		return tableForeignKeys, nil
+	}
+	return nil, nil
+}
+
+// schemaCondition 返回按配置的数据库过滤 TABLE_SCHEMA 的条件及参数
+func (m *MySQL) schemaCondition() (string, []interface{}) {
+	if len(m.Schemas) == 0 {
+		return "TABLE_SCHEMA = DATABASE()", nil
+	}
+	args := make([]interface{}, 0, len(m.Schemas))
+	for _, schema := range m.Schemas {
+		args = append(args, schema)
+	}
+	return "TABLE_SCHEMA IN (" + placeholders(len(m.Schemas)) + ")", args
+}
+
+// tableCondition 返回匹配单张表的条件及参数，表名不带数据库时使用当前数据库
+func tableCondition(tableName string) (string, []interface{}) {
+	schema, name := splitTableName(tableName)
+	return "TABLE_SCHEMA = COALESCE(NULLIF(?, ''), DATABASE()) AND TABLE_NAME = ?", []interface{}{schema, name}
+}
+
+// tableKey 返回批量查询结果的分组键，与 Table.QualifiedName 一致
+func (m *MySQL) tableKey(schema, name string) string {
+	if len(m.Schemas) > 0 {
+		return schema + "." + name
+	}
+	return name
+}
+
+// placeholders 返回 n 个以逗号分隔的 ? 占位符
+func placeholders(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?, ", n), ", ")
+}
+
+// columns 查询满足条件的所有表的列，按表分组
+func (m *MySQL) columns(ctx context.Context, condition string, args []interface{}) (map[string][]Column, error) {
 	query := `
 		SELECT 
+			TABLE_SCHEMA,
+			TABLE_NAME,
 			COLUMN_NAME,
 			DATA_TYPE,
+			COLUMN_TYPE,
 			IS_NULLABLE,
 			COLUMN_KEY,
 			EXTRA,
 			COLUMN_DEFAULT,
-			COLUMN_COMMENT
+			COLUMN_COMMENT,
+			CHARACTER_MAXIMUM_LENGTH,
+			NUMERIC_PRECISION,
+			NUMERIC_SCALE,
+			CHARACTER_SET_NAME,
+			COLLATION_NAME,
+			GENERATION_EXPRESSION
 		FROM 
 			INFORMATION_SCHEMA.COLUMNS 
 		WHERE 
-			TABLE_SCHEMA = DATABASE()
-			AND TABLE_NAME = ?
+			` + condition + `
 		ORDER BY 
-			ORDINAL_POSITION
+			TABLE_SCHEMA, TABLE_NAME, ORDINAL_POSITION
 	`
 	
-	rows, err := m.db.Query(query, tableName)
+	rows, err := m.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("查询列信息失败: %w", err)
 	}
 	defer rows.Close()
 	
-	var columns []Column
+	columns := make(map[string][]Column)
 	for rows.Next() {
 		var col Column
-		var nullable, columnKey, extra string
-		var defaultValue sql.NullString
+		var schema, table, nullable, columnKey, extra string
+		var defaultValue, charset, collation, generatedExpr sql.NullString
+		var length, precision, scale sql.NullInt64
 		
 		if err := rows.Scan(
+			&schema,
+			&table,
 			&col.Name,
 			&col.Type,
+			&col.FullType,
 			&nullable,
 			&columnKey,
 			&extra,
 			&defaultValue,
 			&col.Comment,
+			&length,
+			&precision,
+			&scale,
+			&charset,
+			&collation,
+			&generatedExpr,
 		); err != nil {
 			return nil, fmt.Errorf("扫描列信息失败: %w", err)
 		}
@@ -168,36 +421,173 @@ func (m *MySQL) GetTableColumns(tableName string) ([]Column, error) {
 			col.DefaultValue = defaultValue.String
 		}
 		
-		// 转换为 Go 类型
-		col.GoType = mysqlTypeToGoType(col.Type, col.Nullable)
+		col.Length = length.Int64
+		col.Charset = charset.String
+		col.Collation = collation.String
+		// 整数的 NUMERIC_PRECISION 是类型固有的位数，只保留定点数和浮点数的精度
+		if precisionTypes[col.Type] {
+			col.Precision = int(precision.Int64)
+			col.Scale = int(scale.Int64)
+		}
+		
+		// 生成列的 EXTRA 为 VIRTUAL GENERATED 或 STORED GENERATED，MySQL 8 的 DEFAULT_GENERATED 表示默认值为表达式
+		for _, generated := range []string{"VIRTUAL", "STORED"} {
+			if strings.Contains(extra, generated+" GENERATED") {
+				col.Generated = generated
+				col.GeneratedExpr = generatedExpr.String
+			}
+		}
+		
+		// 转换为 Go 类型，COLUMN_TYPE 保留了 unsigned、显示宽度和枚举值等 DATA_TYPE 不包含的信息
+		col.GoType = mysqlTypeToGoType(col.FullType, col.Nullable)
+		col.EnumValues = parseEnumValues(col.FullType)
 		
-		columns = append(columns, col)
+		key := m.tableKey(schema, table)
+		columns[key] = append(columns[key], col)
 	}
 	
-	return columns, nil
+	return columns, rows.Err()
 }
 
-// mysqlTypeToGoType 将 MySQL 类型转换为 Go 类型
+// indexes 查询满足条件的所有表的索引，按表分组
+func (m *MySQL) indexes(ctx context.Context, condition string, args []interface{}) (map[string][]Index, error) {
+	// 表达式索引（MySQL 8.0.13+）的 COLUMN_NAME 为 NULL，不参与生成
+	query := `
+		SELECT 
+			TABLE_SCHEMA,
+			TABLE_NAME,
+			INDEX_NAME,
+			COLUMN_NAME,
+			NON_UNIQUE
+		FROM 
+			INFORMATION_SCHEMA.STATISTICS 
+		WHERE 
+			` + condition + `
+			AND COLUMN_NAME IS NOT NULL
+		ORDER BY 
+			TABLE_SCHEMA, TABLE_NAME, INDEX_NAME, SEQ_IN_INDEX
+	`
+	
+	rows, err := m.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("查询索引信息失败: %w", err)
+	}
+	defer rows.Close()
+	
+	indexes := make(map[string][]Index)
+	for rows.Next() {
+		var schema, table, indexName, columnName string
+		var nonUnique int
+		
+		if err := rows.Scan(&schema, &table, &indexName, &columnName, &nonUnique); err != nil {
+			return nil, fmt.Errorf("扫描索引信息失败: %w", err)
+		}
+		
+		key := m.tableKey(schema, table)
+		indexes[key] = appendIndexColumn(indexes[key], indexName, columnName, nonUnique == 0, indexName == "PRIMARY")
+	}
+	
+	return indexes, rows.Err()
+}
+
+// foreignKeys 查询满足条件的所有表的外键，按表分组
+func (m *MySQL) foreignKeys(ctx context.Context, condition string, args []interface{}) (map[string][]ForeignKey, error) {
+	query := `
+		SELECT 
+			TABLE_SCHEMA,
+			TABLE_NAME,
+			CONSTRAINT_NAME,
+			COLUMN_NAME,
+			REFERENCED_TABLE_SCHEMA,
+			REFERENCED_TABLE_NAME,
+			REFERENCED_COLUMN_NAME
+		FROM 
+			INFORMATION_SCHEMA.KEY_COLUMN_USAGE 
+		WHERE 
+			` + condition + `
+			AND REFERENCED_TABLE_NAME IS NOT NULL
+		ORDER BY 
+			TABLE_SCHEMA, TABLE_NAME, CONSTRAINT_NAME, ORDINAL_POSITION
+	`
+	
+	rows, err := m.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("查询外键信息失败: %w", err)
+	}
+	defer rows.Close()
+	
+	foreignKeys := make(map[string][]ForeignKey)
+	for rows.Next() {
+		var schema, table, name, column, refSchema, refTable, refColumn string
+		
+		if err := rows.Scan(&schema, &table, &name, &column, &refSchema, &refTable, &refColumn); err != nil {
+			return nil, fmt.Errorf("扫描外键信息失败: %w", err)
+		}
+		
+		// 读取多个数据库时引用表与 Table.QualifiedName 一致，带数据库名
+		if len(m.Schemas) > 0 {
+			refTable = refSchema + "." + refTable
+		}
+		key := m.tableKey(schema, table)
+		foreignKeys[key] = appendForeignKeyColumn(foreignKeys[key], name, column, refTable, refColumn)
+	}
+	
+	return foreignKeys, rows.Err()
+}
+
+// mysqlTypeToGoType 将 MySQL 类型转换为 Go 类型，mysqlType 可以是 DATA_TYPE 或完整的 COLUMN_TYPE
 func mysqlTypeToGoType(mysqlType string, nullable bool) string {
-	// 移除类型参数，如 varchar(255) -> varchar
-	baseType := strings.Split(mysqlType, "(")[0]
-	baseType = strings.ToLower(baseType)
+	// 移除类型参数，如 varchar(255) -> varchar，int(10) unsigned -> int
+	columnType := strings.ToLower(strings.TrimSpace(mysqlType))
+	baseType := strings.TrimSpace(strings.Split(columnType, "(")[0])
+	if fields := strings.Fields(baseType); len(fields) > 0 {
+		baseType = fields[0]
+	}
+	unsigned := strings.Contains(columnType, "unsigned")
 	
 	var goType string
 	switch baseType {
-	case "tinyint", "smallint", "mediumint", "int", "integer":
+	case "tinyint":
+		switch {
+		case strings.HasPrefix(columnType, "tinyint(1)"):
+			goType = "bool"
+		case unsigned:
+			goType = "uint8"
+		default:
+			goType = "int"
+		}
+	case "smallint":
 		goType = "int"
+		if unsigned {
+			goType = "uint16"
+		}
+	case "mediumint", "int", "integer":
+		goType = "int"
+		if unsigned {
+			goType = "uint32"
+		}
 	case "bigint":
 		goType = "int64"
+		if unsigned {
+			goType = "uint64"
+		}
+	case "year":
+		goType = "int16"
+	case "bit":
+		// BIT(n) 以大端字节序返回，由生成的 Bit 类型解码为 uint64
+		goType = "Bit"
 	case "float":
 		goType = "float32"
 	case "double", "decimal", "numeric":
 		goType = "float64"
 	case "char", "varchar", "text", "tinytext", "mediumtext", "longtext":
 		goType = "string"
+	case "enum", "set":
+		// 生成器会为 ENUM/SET 列生成具名的字符串类型
+		goType = "string"
 	case "date", "datetime", "timestamp", "time":
 		goType = "time.Time"
-	case "tinyint(1)", "boolean", "bool":
+	case "boolean", "bool":
 		goType = "bool"
 	case "json":
 		goType = "json.RawMessage"
@@ -213,4 +603,100 @@ func mysqlTypeToGoType(mysqlType string, nullable bool) string {
 	}
 	
 	return goType
+}
+
+// parseEnumValues 解析 enum('a','b') 或 set('a','b') 中的可选值，其他类型返回 nil
+func parseEnumValues(columnType string) []string {
+	lower := strings.ToLower(strings.TrimSpace(columnType))
+	if !strings.HasPrefix(lower, "enum") && !strings.HasPrefix(lower, "set") {
+		return nil
+	}
+	start := strings.Index(columnType, "(")
+	if start < 0 {
+		return nil
+	}
+	
+	var values []string
+	body := columnType[start+1:]
+	for i := 0; i < len(body); i++ {
+		if body[i] != '\'' {
+			continue
+		}
+		
+		// 读取引号内的值，'' 和 \' 表示单引号
+		var value strings.Builder
+		for i++; i < len(body); i++ {
+			if body[i] == '\\' && i+1 < len(body) {
+				i++
+			} else if body[i] == '\'' {
+				if i+1 >= len(body) || body[i+1] != '\'' {
+					break
+				}
+				i++
+			}
+			value.WriteByte(body[i])
+		}
+		values = append(values, value.String())
+	}
+	return values
+}
+
+// lengthTypes 括号参数为最大长度的类型
+var lengthTypes = map[string]bool{
+	"char":              true,
+	"varchar":           true,
+	"character":         true,
+	"character varying": true,
+	"nchar":             true,
+	"nvarchar":          true,
+	"binary":            true,
+	"varbinary":         true,
+}
+
+// precisionTypes 括号参数为精度和小数位数的类型
+var precisionTypes = map[string]bool{
+	"decimal":          true,
+	"numeric":          true,
+	"float":            true,
+	"double":           true,
+	"double precision": true,
+	"real":             true,
+}
+
+// sizedTypes 括号参数为长度的类型
+var sizedTypes = map[string]bool{
+	"char":              true,
+	"character":         true,
+	"varchar":           true,
+	"character varying": true,
+	"binary":            true,
+	"varbinary":         true,
+	"bit":               true,
+	"bit varying":       true,
+}
+
+// parseTypeSize 从 varchar(255)、decimal(10,2) 等列类型中解析长度、精度和小数位数，
+// int(11) 的显示宽度等其他括号参数会被忽略
+func parseTypeSize(columnType string) (int64, int, int) {
+	start := strings.Index(columnType, "(")
+	end := strings.Index(columnType, ")")
+	if start < 0 || end < start {
+		return 0, 0, 0
+	}
+	
+	base := strings.ToLower(strings.TrimSpace(columnType[:start]))
+	args := strings.Split(columnType[start+1:end], ",")
+	switch {
+	case lengthTypes[base]:
+		length, _ := strconv.ParseInt(strings.TrimSpace(args[0]), 10, 64)
+		return length, 0, 0
+	case precisionTypes[base]:
+		precision, _ := strconv.Atoi(strings.TrimSpace(args[0]))
+		var scale int
+		if len(args) > 1 {
+			scale, _ = strconv.Atoi(strings.TrimSpace(args[1]))
+		}
+		return 0, precision, scale
+	}
+	return 0, 0, 0
 }
\ No newline at end of file
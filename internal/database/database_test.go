@@ -0,0 +1,138 @@
+package database
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMySQLTypeToGoType(t *testing.T) {
+	tests := []struct {
+		columnType string
+		nullable   bool
+		expected   string
+	}{
+		{"tinyint(1)", false, "bool"},
+		{"tinyint(4)", false, "int"},
+		{"tinyint unsigned", true, "*uint8"},
+		{"smallint(5) unsigned", false, "uint16"},
+		{"int(10) unsigned", false, "uint32"},
+		{"bigint unsigned", false, "uint64"},
+		{"bigint", false, "int64"},
+		{"year", true, "*int16"},
+		{"bit(8)", false, "Bit"},
+		{"enum('a','b')", false, "string"},
+		{"set('a','b')", true, "*string"},
+		{"varchar", false, "string"},
+		{"geometry", true, "interface{}"},
+	}
+	
+	for _, tt := range tests {
+		if goType := mysqlTypeToGoType(tt.columnType, tt.nullable); goType != tt.expected {
+			t.Errorf("mysqlTypeToGoType(%q, %v) = %s, 期望 %s", tt.columnType, tt.nullable, goType, tt.expected)
+		}
+	}
+}
+
+func TestParseEnumValues(t *testing.T) {
+	tests := []struct {
+		columnType string
+		expected   []string
+	}{
+		{"enum('active','banned')", []string{"active", "banned"}},
+		{"set('a', 'b,c')", []string{"a", "b,c"}},
+		{"enum('it''s','x\\'y')", []string{"it's", "x'y"}},
+		{"enum('')", []string{""}},
+		{"varchar(10)", nil},
+	}
+	
+	for _, tt := range tests {
+		if values := parseEnumValues(tt.columnType); !reflect.DeepEqual(values, tt.expected) {
+			t.Errorf("parseEnumValues(%q) = %q, 期望 %q", tt.columnType, values, tt.expected)
+		}
+	}
+}
+
+func TestPostgresColumnGoType(t *testing.T) {
+	tests := []struct {
+		col      Column
+		expected string
+	}{
+		{Column{Type: "ARRAY", FullType: "int4[]"}, "pq.Int32Array"},
+		{Column{Type: "ARRAY", FullType: "int8[]", Nullable: true}, "pq.Int64Array"},
+		{Column{Type: "ARRAY", FullType: "float8[]"}, "pq.Float64Array"},
+		{Column{Type: "ARRAY", FullType: "text[]"}, "pq.StringArray"},
+		{Column{Type: "ARRAY", FullType: "mood[]"}, "pq.StringArray"},
+		{Column{Type: "USER-DEFINED", FullType: "citext", Nullable: true}, "*string"},
+		{Column{Type: "USER-DEFINED", FullType: "mood", EnumName: "mood"}, "string"},
+		{Column{Type: "USER-DEFINED", FullType: "geometry"}, "interface{}"},
+		{Column{Type: "inet", Nullable: true}, "*Inet"},
+		{Column{Type: "cidr"}, "Cidr"},
+		{Column{Type: "macaddr"}, "MacAddr"},
+		{Column{Type: "interval"}, "Interval"},
+		{Column{Type: "daterange"}, "TimeRange"},
+		{Column{Type: "money"}, "string"},
+		{Column{Type: "xml"}, "string"},
+	}
+	
+	for _, tt := range tests {
+		if goType := postgresColumnGoType(tt.col); goType != tt.expected {
+			t.Errorf("postgresColumnGoType(%+v) = %s, 期望 %s", tt.col, goType, tt.expected)
+		}
+	}
+}
+
+func TestNextvalSequence(t *testing.T) {
+	tests := map[string]string{
+		"nextval('users_id_seq'::regclass)":        "users_id_seq",
+		"nextval('billing.invoice_seq'::regclass)": "billing.invoice_seq",
+		"now()":                                    "",
+		"":                                         "",
+	}
+	for defaultValue, expected := range tests {
+		if sequence := nextvalSequence(defaultValue); sequence != expected {
+			t.Errorf("nextvalSequence(%q): 期望 %q，实际为 %q", defaultValue, expected, sequence)
+		}
+	}
+}
+
+func TestTableQualifiedName(t *testing.T) {
+	if name := (Table{Name: "users"}).QualifiedName(); name != "users" {
+		t.Errorf("期望不带 schema 的表名为 users，实际为 %s", name)
+	}
+	table := Table{Schema: "billing", Name: "invoices"}
+	if name := table.QualifiedName(); name != "billing.invoices" {
+		t.Errorf("期望带 schema 的表名为 billing.invoices，实际为 %s", name)
+	}
+	if schema, name := splitTableName(table.QualifiedName()); schema != "billing" || name != "invoices" {
+		t.Errorf("期望拆分为 billing 和 invoices，实际为 %s 和 %s", schema, name)
+	}
+	if schema, name := splitTableName("users"); schema != "" || name != "users" {
+		t.Errorf("期望不带 schema 时 schema 为空，实际为 %s 和 %s", schema, name)
+	}
+}
+
+func TestTableFilterMatch(t *testing.T) {
+	filter := TableFilter{
+		Include: []string{"users", "billing.invoices", "tmp_users"},
+		Exclude: []string{"tmp_*", "billing.users"},
+	}
+	tests := []struct {
+		table    Table
+		expected bool
+	}{
+		{Table{Name: "users"}, true},
+		{Table{Schema: "billing", Name: "users"}, false},
+		{Table{Schema: "billing", Name: "invoices"}, true},
+		{Table{Name: "invoices"}, false},
+		{Table{Name: "tmp_users"}, false},
+	}
+	for _, tt := range tests {
+		if filter.Match(tt.table) != tt.expected {
+			t.Errorf("表 %s: 期望匹配结果为 %v", tt.table.QualifiedName(), tt.expected)
+		}
+	}
+	
+	if (TableFilter{Prefix: "t_"}).Match(Table{Name: "users"}) {
+		t.Error("期望不带前缀的表不匹配")
+	}
+}
@@ -0,0 +1,1135 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// DDL 从 DDL 文件解析表结构，无需连接数据库
+type DDL struct {
+	Files   []string // DDL 文件列表，按顺序解析
+	Dialect string   // SQL 方言：mysql, postgres
+	tables  []Table
+}
+
+// NewDDL 创建 DDL 数据源，dialect 为空时默认使用 mysql
+func NewDDL(files []string, dialect string) *DDL {
+	if dialect == "" {
+		dialect = "mysql"
+	}
+	return &DDL{Files: files, Dialect: dialect}
+}
+
+// ddlDriver 以驱动名 ddl 注册的文件数据源，解析 database.files 中的 DDL 文件
+type ddlDriver struct{}
+
+func (ddlDriver) Open(dsn string, schemas []string) (Database, error) {
+	return nil, fmt.Errorf("ddl 驱动从 DDL 文件读取表结构，请配置 DDL 文件列表")
+}
+
+func (ddlDriver) ValidateDSN(dsn string) error {
+	return nil
+}
+
+// Dialect 返回空字符串，DDL 文件的方言由 FileDialect 根据配置确定
+func (ddlDriver) Dialect() string {
+	return ""
+}
+
+// GoType 解析 DDL 时已按文件方言设置 GoType，这里只在缺失时按默认的 mysql 方言转换
+func (ddlDriver) GoType(dbType string, nullable bool) string {
+	return mysqlTypeToGoType(dbType, nullable)
+}
+
+func (ddlDriver) ValidateFiles(files []string, dialect string) error {
+	if len(files) == 0 {
+		return fmt.Errorf("DDL 文件列表不能为空")
+	}
+	return validateDDLDialect(dialect)
+}
+
+func (ddlDriver) OpenFiles(files []string, dialect string) (Database, error) {
+	return NewDDL(files, dialect), nil
+}
+
+func (ddlDriver) FileDialect(dialect string) string {
+	if dialect == "" {
+		return "mysql"
+	}
+	return dialect
+}
+
+// validateDDLDialect 校验 DDL 文件的方言，空字符串表示默认的 mysql
+func validateDDLDialect(dialect string) error {
+	if dialect != "" && dialect != "mysql" && dialect != "postgres" {
+		return fmt.Errorf("不支持的 DDL 方言: %s, 支持的方言: mysql, postgres", dialect)
+	}
+	return nil
+}
+
+func (d *DDL) Connect(ctx context.Context) error {
+	if err := validateDDLDialect(d.Dialect); err != nil {
+		return err
+	}
+	
+	parser := newDDLParser(d.Dialect)
+	for _, file := range d.Files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return fmt.Errorf("读取 DDL 文件 %s 失败: %w", file, err)
+		}
+		if err := parser.Parse(string(content)); err != nil {
+			return fmt.Errorf("解析 DDL 文件 %s 失败: %w", file, err)
+		}
+	}
+	
+	d.tables = parser.Tables()
+	return nil
+}
+
+func (d *DDL) Close() error {
+	return nil
+}
+
+func (d *DDL) GetTables(ctx context.Context, filter TableFilter) ([]Table, error) {
+	return filter.Apply(d.tables), nil
+}
+
+func (d *DDL) GetTableColumns(ctx context.Context, tableName string) ([]Column, error) {
+	table, err := d.findTable(tableName)
+	if err != nil {
+		return nil, err
+	}
+	return table.Columns, nil
+}
+
+func (d *DDL) GetTableIndexes(ctx context.Context, tableName string) ([]Index, error) {
+	table, err := d.findTable(tableName)
+	if err != nil {
+		return nil, err
+	}
+	return table.Indexes, nil
+}
+
+func (d *DDL) GetTableForeignKeys(ctx context.Context, tableName string) ([]ForeignKey, error) {
+	table, err := d.findTable(tableName)
+	if err != nil {
+		return nil, err
+	}
+	return table.ForeignKeys, nil
+}
+
+// findTable 按表名查找已解析的表
+func (d *DDL) findTable(tableName string) (*Table, error) {
+	for i := range d.tables {
+		if d.tables[i].Name == tableName {
+			return &d.tables[i], nil
+		}
+	}
+	return nil, fmt.Errorf("表 %s 不存在", tableName)
+}
+
+// ddlParser DDL 语句解析器，支持 CREATE TABLE、ALTER TABLE ... ADD、CREATE INDEX、CREATE TYPE ... AS ENUM 和 COMMENT ON
+type ddlParser struct {
+	dialect string
+	tables  []*Table
+	enums   map[string]ddlEnum // PostgreSQL 枚举类型，键为小写类型名
+	tokens  []token
+	pos     int
+}
+
+// ddlEnum CREATE TYPE ... AS ENUM 定义的枚举类型
+type ddlEnum struct {
+	name   string
+	values []string
+}
+
+// newDDLParser 创建 DDL 解析器
+func newDDLParser(dialect string) *ddlParser {
+	return &ddlParser{dialect: dialect, enums: make(map[string]ddlEnum)}
+}
+
+// Tables 返回解析得到的表，按创建顺序排列
+func (p *ddlParser) Tables() []Table {
+	tables := make([]Table, 0, len(p.tables))
+	for _, table := range p.tables {
+		tables = append(tables, *table)
+	}
+	return tables
+}
+
+// Parse 解析一段 DDL 文本，不支持的语句会被跳过
+func (p *ddlParser) Parse(input string) error {
+	tokens, err := tokenizeSQL(input, p.dialect)
+	if err != nil {
+		return err
+	}
+	p.tokens = tokens
+	p.pos = 0
+	
+	for p.peek().Kind != tokEOF {
+		if p.accept(";") {
+			continue
+		}
+		
+		line := p.peek().Line
+		if err := p.parseStatement(); err != nil {
+			return fmt.Errorf("第 %d 行: %w", line, err)
+		}
+		p.skipStatement()
+	}
+	
+	return nil
+}
+
+// parseStatement 解析单条语句
+func (p *ddlParser) parseStatement() error {
+	switch {
+	case p.peek().is("CREATE"):
+		p.next()
+		p.acceptKeyword("OR")
+		p.acceptKeyword("REPLACE")
+		p.acceptKeyword("TEMPORARY", "TEMP", "UNLOGGED")
+		switch {
+		case p.acceptKeyword("TABLE"):
+			return p.parseCreateTable()
+		case p.peek().is("UNIQUE", "INDEX"):
+			return p.parseCreateIndex()
+		case p.acceptKeyword("TYPE"):
+			return p.parseCreateType()
+		}
+	case p.peek().is("ALTER") && p.peekAt(1).is("TABLE"):
+		p.skip(2)
+		return p.parseAlterTable()
+	case p.peek().is("COMMENT") && p.peekAt(1).is("ON"):
+		p.skip(2)
+		return p.parseCommentOn()
+	}
+	return nil
+}
+
+// parseCreateTable 解析 CREATE TABLE 语句
+func (p *ddlParser) parseCreateTable() error {
+	if p.acceptKeyword("IF") {
+		p.acceptKeyword("NOT")
+		p.acceptKeyword("EXISTS")
+	}
+	
+	name, err := p.parseQualifiedName()
+	if err != nil {
+		return err
+	}
+	// CREATE TABLE ... AS SELECT / LIKE 等形式没有列定义，跳过
+	if !p.accept("(") {
+		return nil
+	}
+	
+	table := p.table(name)
+	table.Columns = nil
+	table.Indexes = nil
+	table.ForeignKeys = nil
+	
+	for {
+		columns := len(table.Columns)
+		if err := p.parseTableElement(table); err != nil {
+			return err
+		}
+		
+		// SQLite 没有 COMMENT 子句，列定义所在行末尾的 -- 注释作为列注释
+		if p.dialect == "sqlite" && len(table.Columns) > columns && table.Columns[columns].Comment == "" {
+			table.Columns[columns].Comment = p.lineComment()
+		}
+		
+		if p.accept(",") {
+			continue
+		}
+		if p.accept(")") {
+			break
+		}
+		return fmt.Errorf("表 %s 的定义中出现意外的 %q", name, p.peek().Text)
+	}
+	
+	// 表选项：MySQL 的 COMMENT，SQLite 的 STRICT 和 WITHOUT ROWID
+	for p.peek().Kind != tokEOF && !p.peek().isPunct(";") {
+		switch {
+		case p.acceptKeyword("COMMENT"):
+			p.accept("=")
+			table.Comment = p.next().Value
+		case p.acceptKeyword("STRICT"):
+			table.Strict = true
+		case p.peek().is("WITHOUT") && p.peekAt(1).is("ROWID"):
+			p.skip(2)
+			table.WithoutRowid = true
+		default:
+			p.next()
+		}
+	}
+	
+	if p.dialect == "sqlite" {
+		markRowidAlias(table)
+	}
+	
+	return nil
+}
+
+// markRowidAlias 将 SQLite 的 INTEGER PRIMARY KEY 列标记为自增：该列是 rowid 的别名，
+// 插入时省略会自动分配。WITHOUT ROWID 表和复合主键没有 rowid 别名
+func markRowidAlias(table *Table) {
+	if table.WithoutRowid {
+		return
+	}
+	var keys []int
+	for i, col := range table.Columns {
+		if col.IsPrimaryKey {
+			keys = append(keys, i)
+		}
+	}
+	if len(keys) == 1 && strings.EqualFold(table.Columns[keys[0]].Type, "INTEGER") {
+		table.Columns[keys[0]].IsAutoIncr = true
+	}
+}
+
+// lineComment 返回刚解析完的元素所在行末尾的 -- 注释，注释可以位于分隔的逗号之前或之后
+func (p *ddlParser) lineComment() string {
+	if p.pos == 0 {
+		return ""
+	}
+	line := p.tokens[p.pos-1].Line
+	for i := p.pos; i < len(p.tokens); i++ {
+		tok := p.tokens[i]
+		switch {
+		case tok.Kind == tokComment && tok.Line == line && strings.HasPrefix(tok.Text, "--"):
+			return tok.Value
+		case tok.Kind == tokComment, tok.isPunct(",") && tok.Line == line:
+			continue
+		}
+		return ""
+	}
+	return ""
+}
+
+// parseTableElement 解析表定义中的列或约束
+func (p *ddlParser) parseTableElement(table *Table) error {
+	constraintName := ""
+	if p.acceptKeyword("CONSTRAINT") {
+		if !p.peek().is("PRIMARY", "UNIQUE", "FOREIGN", "CHECK") {
+			constraintName = p.next().Value
+		}
+	}
+	
+	tok := p.peek()
+	switch {
+	case tok.is("PRIMARY") && p.peekAt(1).is("KEY"):
+		p.skip(2)
+		p.skipIndexName()
+		columns, err := p.parseColumnList()
+		if err != nil {
+			return err
+		}
+		p.addPrimaryKey(table, constraintName, columns)
+		return p.skipElement()
+	
+	case tok.is("UNIQUE"):
+		p.next()
+		p.acceptKeyword("KEY", "INDEX")
+		indexName := constraintName
+		if name := p.skipIndexName(); name != "" {
+			indexName = name
+		}
+		columns, err := p.parseColumnList()
+		if err != nil {
+			return err
+		}
+		p.addIndex(table, indexName, columns, true)
+		return p.skipElement()
+	
+	case tok.is("FOREIGN") && p.peekAt(1).is("KEY"):
+		p.skip(2)
+		if name := p.skipIndexName(); name != "" && constraintName == "" {
+			constraintName = name
+		}
+		return p.parseForeignKey(table, constraintName, nil)
+	
+	case tok.is("KEY", "INDEX") && p.dialect == "mysql":
+		p.next()
+		indexName := p.skipIndexName()
+		columns, err := p.parseColumnList()
+		if err != nil {
+			return err
+		}
+		p.addIndex(table, indexName, columns, false)
+		return p.skipElement()
+	
+	case tok.is("CHECK", "EXCLUDE", "FULLTEXT", "SPATIAL", "LIKE"):
+		return p.skipElement()
+	}
+	
+	return p.parseColumn(table)
+}
+
+// parseColumn 解析列定义
+func (p *ddlParser) parseColumn(table *Table) (err error) {
+	nameTok := p.next()
+	if nameTok.Kind != tokIdent && nameTok.Kind != tokQuotedIdent {
+		return fmt.Errorf("表 %s 中期望列名，实际为 %q", table.Name, nameTok.Text)
+	}
+	
+	typ := p.parseType()
+	col := Column{
+		Name:     nameTok.Value,
+		Type:     typ.base,
+		Nullable: true,
+	}
+	
+	// 数组的类型参数属于元素类型，不记录长度和精度
+	if typ.base != "ARRAY" {
+		col.Length, col.Precision, col.Scale = parseTypeSize(typ.full)
+	}
+	
+	// MySQL 保留完整类型，与 INFORMATION_SCHEMA.COLUMNS.COLUMN_TYPE 的格式一致
+	if p.dialect == "mysql" {
+		col.FullType, col.EnumValues = mysqlColumnType(typ)
+	}
+	
+	// PostgreSQL 的数组列与 information_schema 一致，完整类型记录元素类型
+	if typ.base == "ARRAY" && p.dialect == "postgres" {
+		col.FullType = typ.elem + "[]"
+	}
+	
+	// PostgreSQL 的枚举列与 information_schema 一致，类型记为 USER-DEFINED
+	if enum, ok := p.enums[typ.base]; ok && p.dialect == "postgres" {
+		col.Type = "USER-DEFINED"
+		col.EnumName = enum.name
+		col.EnumValues = enum.values
+	}
+	
+	// PostgreSQL 的 serial 类型是带序列默认值的整数列
+	if p.dialect == "postgres" {
+		if base, ok := postgresSerialTypes[typ.base]; ok {
+			col.Type = base
+			col.IsAutoIncr = true
+			col.Nullable = false
+			col.Sequence = fmt.Sprintf("%s_%s_seq", table.Name, col.Name)
+			col.DefaultValue = fmt.Sprintf("nextval('%s'::regclass)", col.Sequence)
+		}
+	}
+	
+	for {
+		tok := p.peek()
+		switch {
+		case tok.Kind == tokEOF, tok.isPunct(","), tok.isPunct(")"):
+			p.addColumn(table, col)
+			return nil
+		
+		case tok.is("NOT") && p.peekAt(1).is("NULL"):
+			p.skip(2)
+			col.Nullable = false
+		
+		case tok.is("NULL"):
+			p.next()
+			col.Nullable = true
+		
+		case tok.is("DEFAULT") && p.peekAt(1).is("NULL"):
+			// 与数据库元数据保持一致，DEFAULT NULL 视为没有默认值
+			p.skip(2)
+		
+		case tok.is("DEFAULT"):
+			p.next()
+			col.DefaultValue = p.parseDefault()
+		
+		case tok.is("AUTO_INCREMENT", "AUTOINCREMENT"):
+			p.next()
+			col.IsAutoIncr = true
+		
+		case tok.is("PRIMARY") && p.peekAt(1).is("KEY"):
+			p.skip(2)
+			col.IsPrimaryKey = true
+			col.Nullable = false
+			p.acceptKeyword("ASC", "DESC")
+		
+		case tok.is("UNIQUE"):
+			p.next()
+			p.acceptKeyword("KEY")
+			defer p.addIndex(table, "", []string{col.Name}, true)
+		
+		case tok.is("COMMENT"):
+			p.next()
+			col.Comment = p.next().Value
+		
+		case tok.is("REFERENCES"):
+			colName := col.Name
+			defer func(pos int) {
+				saved := p.pos
+				p.pos = pos
+				if fkErr := p.parseForeignKey(table, "", []string{colName}); fkErr != nil && err == nil {
+					err = fkErr
+				}
+				p.pos = saved
+			}(p.pos)
+			p.next()
+			p.parseQualifiedName()
+			if p.peek().isPunct("(") {
+				p.skipParens()
+			}
+		
+		case tok.is("GENERATED"), tok.is("AS") && p.peekAt(1).isPunct("("):
+			// GENERATED ALWAYS AS IDENTITY / GENERATED BY DEFAULT AS IDENTITY / [GENERATED ALWAYS] AS (expr) [VIRTUAL | STORED]
+			var generation []string
+			for !p.peek().is("AS") && p.peek().Kind != tokEOF {
+				generation = append(generation, strings.ToUpper(p.next().Value))
+			}
+			p.next()
+			if p.acceptKeyword("IDENTITY") {
+				// 与 information_schema.columns.identity_generation 一致，序列名使用 PostgreSQL 的默认命名
+				col.Identity = strings.Join(generation[1:], " ")
+				col.Sequence = fmt.Sprintf("%s_%s_seq", table.Name, col.Name)
+				col.IsAutoIncr = true
+				col.Nullable = false
+				if p.peek().isPunct("(") {
+					p.skipParens()
+				}
+			} else if p.peek().isPunct("(") {
+				expr := p.skipParens()
+				col.GeneratedExpr = strings.TrimSpace(expr[1 : len(expr)-1])
+				// MySQL 和 SQLite 默认为虚拟生成列，PostgreSQL 只支持存储生成列
+				col.Generated = "VIRTUAL"
+				if p.dialect == "postgres" {
+					col.Generated = "STORED"
+				}
+				if kind := p.peek(); kind.is("VIRTUAL", "STORED") {
+					p.next()
+					col.Generated = strings.ToUpper(kind.Value)
+				}
+			}
+		
+		case tok.is("CHARACTER") && p.peekAt(1).is("SET"):
+			p.skip(2)
+			col.Charset = p.next().Value
+		
+		case tok.is("CHARSET"):
+			p.next()
+			col.Charset = p.next().Value
+		
+		case tok.is("COLLATE"):
+			p.next()
+			col.Collation = p.next().Value
+		
+		case tok.is("CONSTRAINT"):
+			p.skip(2)
+		
+		case tok.isPunct("("):
+			p.skipParens()
+		
+		default:
+			p.next()
+		}
+	}
+}
+
+// parseForeignKey 解析 FOREIGN KEY (...) REFERENCES t (...)，columns 非空时为列级外键
+func (p *ddlParser) parseForeignKey(table *Table, name string, columns []string) error {
+	var err error
+	if columns == nil {
+		if columns, err = p.parseColumnList(); err != nil {
+			return err
+		}
+	}
+	if !p.acceptKeyword("REFERENCES") {
+		return fmt.Errorf("表 %s 的外键缺少 REFERENCES", table.Name)
+	}
+	refTable, err := p.parseQualifiedName()
+	if err != nil {
+		return err
+	}
+	
+	var refColumns []string
+	if p.peek().isPunct("(") {
+		if refColumns, err = p.parseColumnList(); err != nil {
+			return err
+		}
+	} else if ref := p.findTable(refTable); ref != nil {
+		// 省略引用列时引用主键
+		for _, col := range ref.Columns {
+			if col.IsPrimaryKey {
+				refColumns = append(refColumns, col.Name)
+			}
+		}
+	}
+	
+	if name == "" {
+		name = p.defaultForeignKeyName(table, columns)
+	}
+	table.ForeignKeys = append(table.ForeignKeys, ForeignKey{
+		Name:       name,
+		Columns:    columns,
+		RefTable:   refTable,
+		RefColumns: refColumns,
+	})
+	
+	return p.skipElement()
+}
+
+// parseAlterTable 解析 ALTER TABLE ... ADD 语句，其他子句会被跳过
+func (p *ddlParser) parseAlterTable() error {
+	p.acceptKeyword("ONLY")
+	if p.acceptKeyword("IF") {
+		p.acceptKeyword("EXISTS")
+	}
+	name, err := p.parseQualifiedName()
+	if err != nil {
+		return err
+	}
+	table := p.findTable(name)
+	if table == nil {
+		return nil
+	}
+	
+	for {
+		if p.acceptKeyword("ADD") {
+			switch {
+			case p.peek().is("CONSTRAINT", "PRIMARY", "UNIQUE", "FOREIGN", "CHECK"),
+				p.peek().is("INDEX", "KEY") && p.dialect == "mysql":
+				if err := p.parseTableElement(table); err != nil {
+					return err
+				}
+			default:
+				p.acceptKeyword("COLUMN")
+				if p.acceptKeyword("IF") {
+					p.acceptKeyword("NOT")
+					p.acceptKeyword("EXISTS")
+				}
+				if err := p.parseColumn(table); err != nil {
+					return err
+				}
+			}
+		} else if err := p.skipElement(); err != nil {
+			return err
+		}
+		
+		if !p.accept(",") {
+			return nil
+		}
+	}
+}
+
+// parseCreateIndex 解析 CREATE [UNIQUE] INDEX 语句，跳过部分索引和表达式索引
+func (p *ddlParser) parseCreateIndex() error {
+	unique := p.acceptKeyword("UNIQUE")
+	p.acceptKeyword("INDEX")
+	p.acceptKeyword("CONCURRENTLY")
+	if p.acceptKeyword("IF") {
+		p.acceptKeyword("NOT")
+		p.acceptKeyword("EXISTS")
+	}
+	
+	indexName := ""
+	if !p.peek().is("ON") {
+		name, err := p.parseQualifiedName()
+		if err != nil {
+			return err
+		}
+		indexName = name
+	}
+	if !p.acceptKeyword("ON") {
+		return nil
+	}
+	p.acceptKeyword("ONLY")
+	tableName, err := p.parseQualifiedName()
+	if err != nil {
+		return err
+	}
+	if p.acceptKeyword("USING") {
+		p.next()
+	}
+	
+	columns, err := p.parseColumnList()
+	if err != nil || columns == nil {
+		return nil
+	}
+	for p.peek().Kind != tokEOF && !p.peek().isPunct(";") {
+		if p.acceptKeyword("WHERE") {
+			return nil
+		}
+		p.next()
+	}
+	
+	if table := p.findTable(tableName); table != nil {
+		p.addIndex(table, indexName, columns, unique)
+	}
+	return nil
+}
+
+// parseCreateType 解析 PostgreSQL 的 CREATE TYPE ... AS ENUM 语句，其他自定义类型会被跳过
+func (p *ddlParser) parseCreateType() error {
+	name, err := p.parseQualifiedName()
+	if err != nil {
+		return err
+	}
+	if !p.acceptKeyword("AS") || !p.acceptKeyword("ENUM") || !p.accept("(") {
+		return nil
+	}
+	
+	enum := ddlEnum{name: name}
+	for !p.accept(")") {
+		tok := p.next()
+		switch {
+		case tok.Kind == tokEOF:
+			return fmt.Errorf("枚举类型 %s 的定义不完整", name)
+		case tok.Kind == tokString:
+			enum.values = append(enum.values, tok.Value)
+		}
+	}
+	p.enums[strings.ToLower(name)] = enum
+	return nil
+}
+
+// parseCommentOn 解析 PostgreSQL 的 COMMENT ON TABLE/COLUMN 语句
+func (p *ddlParser) parseCommentOn() error {
+	switch {
+	case p.acceptKeyword("TABLE"):
+		name, err := p.parseQualifiedName()
+		if err != nil {
+			return err
+		}
+		if !p.acceptKeyword("IS") {
+			return nil
+		}
+		if table := p.findTable(name); table != nil {
+			table.Comment = p.next().Value
+		}
+	
+	case p.acceptKeyword("COLUMN"):
+		parts := p.parseNameParts()
+		if len(parts) < 2 || !p.acceptKeyword("IS") {
+			return nil
+		}
+		comment := p.next().Value
+		if table := p.findTable(parts[len(parts)-2]); table != nil {
+			for i := range table.Columns {
+				if table.Columns[i].Name == parts[len(parts)-1] {
+					table.Columns[i].Comment = comment
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// ddlType 解析得到的列类型
+type ddlType struct {
+	base string // 小写基础类型，如 varchar、timestamp with time zone
+	full string // 包含参数和修饰符的完整类型，如 varchar(100)、int unsigned
+	elem string // PostgreSQL 数组的元素类型，使用 udt_name 的写法，如 int4
+}
+
+// parseType 解析列类型，支持多词类型、类型参数和数组
+func (p *ddlParser) parseType() ddlType {
+	var words []string
+	var full strings.Builder
+	
+	word := strings.ToLower(p.next().Value)
+	words = append(words, word)
+	full.WriteString(word)
+	
+	for {
+		tok := p.peek()
+		switch {
+		case tok.isPunct("("):
+			full.WriteString(p.skipParens())
+		case tok.isPunct("[") && p.peekAt(1).isPunct("]"):
+			p.skip(2)
+			full.WriteString("[]")
+		case tok.is("PRECISION", "VARYING") ||
+			(tok.is("WITH", "WITHOUT") && p.peekAt(1).is("TIME")) ||
+			(tok.is("TIME", "ZONE") && len(words) > 1):
+			p.next()
+			words = append(words, strings.ToLower(tok.Value))
+			full.WriteString(" " + strings.ToLower(tok.Value))
+		case tok.is("UNSIGNED", "SIGNED", "ZEROFILL"):
+			p.next()
+			full.WriteString(" " + strings.ToLower(tok.Value))
+		case tok.is("ARRAY"):
+			p.next()
+			full.WriteString("[]")
+		default:
+			base := strings.Join(words, " ")
+			if p.dialect == "postgres" {
+				if alias, ok := postgresTypeAliases[base]; ok {
+					base = alias
+				}
+			}
+			if strings.HasSuffix(full.String(), "[]") {
+				elem := base
+				if udtName, ok := postgresUDTNames[elem]; ok {
+					elem = udtName
+				}
+				return ddlType{base: "ARRAY", full: full.String(), elem: elem}
+			}
+			return ddlType{base: base, full: full.String()}
+		}
+	}
+}
+
+// parseDefault 解析 DEFAULT 表达式，返回表达式文本
+func (p *ddlParser) parseDefault() string {
+	var parts []string
+	tok := p.next()
+	switch {
+	case tok.isPunct("("):
+		p.pos--
+		parts = append(parts, p.skipParens())
+	case tok.isPunct("-"), tok.isPunct("+"):
+		parts = append(parts, tok.Text+p.next().Text)
+	case tok.Kind == tokString && p.dialect == "mysql":
+		parts = append(parts, tok.Value)
+	default:
+		parts = append(parts, tok.Text)
+		if p.peek().isPunct("(") {
+			parts = append(parts, p.skipParens())
+		}
+	}
+	
+	// PostgreSQL 的类型转换，如 'pending'::character varying
+	for p.peek().isPunct("::") {
+		p.next()
+		parts = append(parts, "::"+p.parseType().full)
+	}
+	
+	return strings.Join(parts, "")
+}
+
+// parseColumnList 解析括号内的列名列表，包含表达式时返回 nil
+func (p *ddlParser) parseColumnList() ([]string, error) {
+	if !p.accept("(") {
+		return nil, fmt.Errorf("期望 '('，实际为 %q", p.peek().Text)
+	}
+	
+	var columns []string
+	expression := false
+	for {
+		tok := p.next()
+		switch {
+		case tok.Kind == tokEOF:
+			return nil, fmt.Errorf("列列表未闭合")
+		case tok.Kind == tokIdent || tok.Kind == tokQuotedIdent:
+			columns = append(columns, tok.Value)
+		default:
+			expression = true
+		}
+		
+		// 跳过前缀长度、排序方向和操作符类等修饰
+		for !p.peek().isPunct(",") && !p.peek().isPunct(")") && p.peek().Kind != tokEOF {
+			if p.peek().isPunct("(") {
+				if p.dialect != "mysql" {
+					expression = true
+				}
+				p.skipParens()
+				continue
+			}
+			p.next()
+		}
+		
+		if p.accept(")") {
+			break
+		}
+		p.accept(",")
+	}
+	
+	if expression {
+		return nil, nil
+	}
+	return columns, nil
+}
+
+// parseQualifiedName 解析可能带 schema 前缀的名称，返回最后一段
+func (p *ddlParser) parseQualifiedName() (string, error) {
+	parts := p.parseNameParts()
+	if len(parts) == 0 {
+		return "", fmt.Errorf("期望名称，实际为 %q", p.peek().Text)
+	}
+	return parts[len(parts)-1], nil
+}
+
+// parseNameParts 解析以点分隔的名称
+func (p *ddlParser) parseNameParts() []string {
+	var parts []string
+	for {
+		tok := p.peek()
+		if tok.Kind != tokIdent && tok.Kind != tokQuotedIdent {
+			return parts
+		}
+		p.next()
+		parts = append(parts, tok.Value)
+		if !p.accept(".") {
+			return parts
+		}
+	}
+}
+
+// skipIndexName 跳过可选的索引名和 USING 子句，返回索引名
+func (p *ddlParser) skipIndexName() string {
+	name := ""
+	if tok := p.peek(); (tok.Kind == tokIdent || tok.Kind == tokQuotedIdent) && !tok.is("USING") {
+		name = p.next().Value
+	}
+	if p.acceptKeyword("USING") {
+		p.next()
+	}
+	return name
+}
+
+// skipParens 跳过一组括号，返回包含括号的原始文本
+func (p *ddlParser) skipParens() string {
+	var text strings.Builder
+	depth := 0
+	for {
+		tok := p.next()
+		if tok.Kind == tokEOF {
+			return text.String()
+		}
+		if tok.Kind == tokComment {
+			continue
+		}
+		if text.Len() > 1 && !tok.isPunct(")") && !tok.isPunct(",") && !strings.HasSuffix(text.String(), "(") {
+			text.WriteString(" ")
+		}
+		text.WriteString(tok.Text)
+		switch {
+		case tok.isPunct("("):
+			depth++
+		case tok.isPunct(")"):
+			depth--
+			if depth == 0 {
+				return text.String()
+			}
+		}
+	}
+}
+
+// skipElement 跳到当前表元素或 ALTER 子句的结尾（同层的逗号或右括号）
+func (p *ddlParser) skipElement() error {
+	for {
+		tok := p.peek()
+		switch {
+		case tok.Kind == tokEOF, tok.isPunct(","), tok.isPunct(")"), tok.isPunct(";"):
+			return nil
+		case tok.isPunct("("):
+			p.skipParens()
+		default:
+			p.next()
+		}
+	}
+}
+
+// skipStatement 跳到语句结尾
+func (p *ddlParser) skipStatement() {
+	for p.peek().Kind != tokEOF {
+		if p.next().isPunct(";") {
+			return
+		}
+	}
+}
+
+// table 获取或创建表
+func (p *ddlParser) table(name string) *Table {
+	if table := p.findTable(name); table != nil {
+		return table
+	}
+	table := &Table{Name: name}
+	p.tables = append(p.tables, table)
+	return table
+}
+
+// findTable 按名称查找已解析的表
+func (p *ddlParser) findTable(name string) *Table {
+	for _, table := range p.tables {
+		if table.Name == name {
+			return table
+		}
+	}
+	return nil
+}
+
+// addColumn 添加列并计算 Go 类型
+func (p *ddlParser) addColumn(table *Table, col Column) {
+	if col.IsPrimaryKey {
+		p.addPrimaryKey(table, "", []string{col.Name})
+	}
+	col.IsPrimaryKey = col.IsPrimaryKey || table.hasPrimaryKeyColumn(col.Name)
+	col.GoType = p.goType(col)
+	table.Columns = append(table.Columns, col)
+}
+
+// addPrimaryKey 标记主键列并记录主键索引
+func (p *ddlParser) addPrimaryKey(table *Table, name string, columns []string) {
+	if name == "" || p.dialect == "mysql" {
+		name = "PRIMARY"
+		if p.dialect == "postgres" {
+			name = table.Name + "_pkey"
+		}
+	}
+	for i := range table.Columns {
+		for _, column := range columns {
+			if table.Columns[i].Name == column {
+				table.Columns[i].IsPrimaryKey = true
+				table.Columns[i].Nullable = false
+				table.Columns[i].GoType = p.goType(table.Columns[i])
+			}
+		}
+	}
+	table.Indexes = append(table.Indexes, Index{Name: name, Columns: columns, IsUnique: true, IsPrimary: true})
+}
+
+// addIndex 记录索引，未命名时按数据库的默认规则命名
+func (p *ddlParser) addIndex(table *Table, name string, columns []string, unique bool) {
+	if columns == nil {
+		return
+	}
+	if name == "" {
+		switch {
+		case p.dialect == "postgres" && unique:
+			name = fmt.Sprintf("%s_%s_key", table.Name, strings.Join(columns, "_"))
+		case p.dialect == "postgres":
+			name = fmt.Sprintf("%s_%s_idx", table.Name, strings.Join(columns, "_"))
+		default:
+			name = columns[0]
+		}
+	}
+	table.Indexes = append(table.Indexes, Index{Name: name, Columns: columns, IsUnique: unique})
+}
+
+// defaultForeignKeyName 按数据库的默认规则生成外键名
+func (p *ddlParser) defaultForeignKeyName(table *Table, columns []string) string {
+	if p.dialect == "postgres" {
+		return fmt.Sprintf("%s_%s_fkey", table.Name, strings.Join(columns, "_"))
+	}
+	return fmt.Sprintf("%s_ibfk_%d", table.Name, len(table.ForeignKeys)+1)
+}
+
+// goType 根据方言将列类型转换为 Go 类型
+func (p *ddlParser) goType(col Column) string {
+	if p.dialect == "postgres" {
+		return postgresColumnGoType(col)
+	}
+	if col.FullType != "" {
+		return mysqlTypeToGoType(col.FullType, col.Nullable)
+	}
+	return mysqlTypeToGoType(col.Type, col.Nullable)
+}
+
+// mysqlColumnType 将解析得到的类型转换为 COLUMN_TYPE 格式，并提取 ENUM/SET 的可选值
+func mysqlColumnType(typ ddlType) (string, []string) {
+	values := parseEnumValues(typ.full)
+	if values == nil {
+		return strings.ReplaceAll(typ.full, ", ", ","), nil
+	}
+	
+	quoted := make([]string, len(values))
+	for i, value := range values {
+		quoted[i] = "'" + strings.ReplaceAll(value, "'", "''") + "'"
+	}
+	return fmt.Sprintf("%s(%s)", typ.base, strings.Join(quoted, ",")), values
+}
+
+// hasPrimaryKeyColumn 判断列是否属于已声明的主键
+func (t *Table) hasPrimaryKeyColumn(name string) bool {
+	for _, index := range t.Indexes {
+		if !index.IsPrimary {
+			continue
+		}
+		for _, column := range index.Columns {
+			if column == name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// peek 返回当前词法单元（跳过注释）
+func (p *ddlParser) peek() token {
+	return p.peekAt(0)
+}
+
+// peekAt 返回当前位置之后第 n 个非注释词法单元
+func (p *ddlParser) peekAt(n int) token {
+	for i := p.pos; i < len(p.tokens); i++ {
+		if p.tokens[i].Kind == tokComment {
+			continue
+		}
+		if n == 0 {
+			return p.tokens[i]
+		}
+		n--
+	}
+	return token{Kind: tokEOF}
+}
+
+// next 消费并返回当前词法单元（跳过注释）
+func (p *ddlParser) next() token {
+	for p.pos < len(p.tokens) {
+		tok := p.tokens[p.pos]
+		p.pos++
+		if tok.Kind != tokComment {
+			return tok
+		}
+	}
+	return token{Kind: tokEOF}
+}
+
+// skip 消费 n 个词法单元（跳过注释）
+func (p *ddlParser) skip(n int) {
+	for i := 0; i < n; i++ {
+		p.next()
+	}
+}
+
+// accept 当前词法单元为指定标点时消费它
+func (p *ddlParser) accept(punct string) bool {
+	if p.peek().isPunct(punct) {
+		p.next()
+		return true
+	}
+	return false
+}
+
+// acceptKeyword 当前词法单元为指定关键字之一时消费它
+func (p *ddlParser) acceptKeyword(keywords ...string) bool {
+	if p.peek().is(keywords...) {
+		p.next()
+		return true
+	}
+	return false
+}
+
+// postgresSerialTypes PostgreSQL serial 类型对应的整数类型
+var postgresSerialTypes = map[string]string{
+	"smallserial": "smallint",
+	"serial2":     "smallint",
+	"serial":      "integer",
+	"serial4":     "integer",
+	"bigserial":   "bigint",
+	"serial8":     "bigint",
+}
+
+// postgresTypeAliases PostgreSQL 类型别名与 information_schema 中 data_type 的对应关系
+var postgresTypeAliases = map[string]string{
+	"int":         "integer",
+	"int4":        "integer",
+	"int2":        "smallint",
+	"int8":        "bigint",
+	"float4":      "real",
+	"float8":      "double precision",
+	"float":       "double precision",
+	"decimal":     "numeric",
+	"bool":        "boolean",
+	"varchar":     "character varying",
+	"char":        "character",
+	"bpchar":      "character",
+	"timestamp":   "timestamp without time zone",
+	"timestamptz": "timestamp with time zone",
+	"time":        "time without time zone",
+	"timetz":      "time with time zone",
+}
@@ -0,0 +1,231 @@
+package database
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// tokenKind 词法单元类型
+type tokenKind int
+
+const (
+	tokEOF         tokenKind = iota
+	tokIdent                 // 关键字或未加引号的标识符
+	tokQuotedIdent           // `name`、"name" 或 [name]
+	tokString                // 'text'、$$text$$
+	tokNumber                // 数字字面量
+	tokPunct                 // 标点和运算符
+	tokComment               // -- 注释或 /* */ 注释
+)
+
+// token 词法单元
+type token struct {
+	Kind  tokenKind
+	Text  string // 原始文本
+	Value string // 去除引号后的值
+	Line  int    // 所在行号，从 1 开始
+}
+
+// is 判断词法单元是否为指定关键字（不区分大小写）
+func (t token) is(keywords ...string) bool {
+	if t.Kind != tokIdent {
+		return false
+	}
+	for _, kw := range keywords {
+		if strings.EqualFold(t.Text, kw) {
+			return true
+		}
+	}
+	return false
+}
+
+// isPunct 判断词法单元是否为指定标点
+func (t token) isPunct(punct string) bool {
+	return t.Kind == tokPunct && t.Text == punct
+}
+
+// tokenizeSQL 将 SQL 文本切分为词法单元，dialect 影响字符串转义规则
+func tokenizeSQL(input, dialect string) ([]token, error) {
+	var tokens []token
+	src := []rune(input)
+	line := 1
+	i := 0
+	
+	for i < len(src) {
+		r := src[i]
+		start := i
+		startLine := line
+		
+		switch {
+		case r == '\n':
+			line++
+			i++
+			continue
+		case unicode.IsSpace(r):
+			i++
+			continue
+		
+		case r == '-' && i+1 < len(src) && src[i+1] == '-':
+			for i < len(src) && src[i] != '\n' {
+				i++
+			}
+			text := string(src[start:i])
+			tokens = append(tokens, token{Kind: tokComment, Text: text, Value: strings.TrimSpace(text[2:]), Line: startLine})
+			continue
+		
+		case r == '#' && dialect == "mysql":
+			for i < len(src) && src[i] != '\n' {
+				i++
+			}
+			text := string(src[start:i])
+			tokens = append(tokens, token{Kind: tokComment, Text: text, Value: strings.TrimSpace(text[1:]), Line: startLine})
+			continue
+		
+		case r == '/' && i+1 < len(src) && src[i+1] == '*':
+			end := indexRunes(src, i+2, "*/")
+			if end < 0 {
+				return nil, fmt.Errorf("第 %d 行: 注释未闭合", startLine)
+			}
+			i = end + 2
+			text := string(src[start:i])
+			line += strings.Count(text, "\n")
+			tokens = append(tokens, token{Kind: tokComment, Text: text, Value: strings.TrimSpace(text[2 : len(text)-2]), Line: startLine})
+			continue
+		
+		case r == '\'':
+			value, n, lines, err := scanQuoted(src[i:], '\'', dialect == "mysql")
+			if err != nil {
+				return nil, fmt.Errorf("第 %d 行: %w", startLine, err)
+			}
+			i += n
+			line += lines
+			tokens = append(tokens, token{Kind: tokString, Text: string(src[start:i]), Value: value, Line: startLine})
+			continue
+		
+		case r == '`' || r == '"':
+			value, n, lines, err := scanQuoted(src[i:], r, false)
+			if err != nil {
+				return nil, fmt.Errorf("第 %d 行: %w", startLine, err)
+			}
+			i += n
+			line += lines
+			tokens = append(tokens, token{Kind: tokQuotedIdent, Text: string(src[start:i]), Value: value, Line: startLine})
+			continue
+
+		case r == '[' && dialect == "sqlite":
+			end := indexRunes(src, i, "]")
+			if end < 0 {
+				return nil, fmt.Errorf("第 %d 行: 标识符未闭合", startLine)
+			}
+			i = end + 1
+			text := string(src[start:i])
+			tokens = append(tokens, token{Kind: tokQuotedIdent, Text: text, Value: text[1 : len(text)-1], Line: startLine})
+			continue
+
+		case r == '$' && dialect == "postgres":
+			// 美元符号引用的字符串：$$...$$ 或 $tag$...$tag$
+			j := i + 1
+			for j < len(src) && (src[j] == '_' || unicode.IsLetter(src[j]) || unicode.IsDigit(src[j])) {
+				j++
+			}
+			if j < len(src) && src[j] == '$' {
+				tag := string(src[i : j+1])
+				end := indexRunes(src, j+1, tag)
+				if end < 0 {
+					return nil, fmt.Errorf("第 %d 行: 字符串 %s 未闭合", startLine, tag)
+				}
+				body := string(src[j+1 : end])
+				i = end + len([]rune(tag))
+				line += strings.Count(body, "\n")
+				tokens = append(tokens, token{Kind: tokString, Text: string(src[start:i]), Value: body, Line: startLine})
+				continue
+			}
+			i++
+			tokens = append(tokens, token{Kind: tokPunct, Text: "$", Line: startLine})
+			continue
+
+		case unicode.IsDigit(r) || (r == '.' && i+1 < len(src) && unicode.IsDigit(src[i+1])):
+			for i < len(src) && (unicode.IsDigit(src[i]) || src[i] == '.' || src[i] == 'e' || src[i] == 'E') {
+				i++
+			}
+			text := string(src[start:i])
+			tokens = append(tokens, token{Kind: tokNumber, Text: text, Value: text, Line: startLine})
+			continue
+
+		case r == '_' || unicode.IsLetter(r):
+			for i < len(src) && (src[i] == '_' || src[i] == '$' || unicode.IsLetter(src[i]) || unicode.IsDigit(src[i])) {
+				i++
+			}
+			text := string(src[start:i])
+			tokens = append(tokens, token{Kind: tokIdent, Text: text, Value: text, Line: startLine})
+			continue
+		}
+
+		// 标点与运算符，:: 作为一个整体便于解析 PostgreSQL 类型转换
+		if r == ':' && i+1 < len(src) && src[i+1] == ':' {
+			i += 2
+		} else {
+			i++
+		}
+		text := string(src[start:i])
+		tokens = append(tokens, token{Kind: tokPunct, Text: text, Value: text, Line: startLine})
+	}
+
+	tokens = append(tokens, token{Kind: tokEOF, Line: line})
+	return tokens, nil
+}
+
+// scanQuoted 扫描引号包裹的内容，支持重复引号转义，backslash 为 true 时支持反斜杠转义。
+// 返回去除引号后的值、消耗的字符数和跨越的行数。
+func scanQuoted(src []rune, quote rune, backslash bool) (string, int, int, error) {
+	var value strings.Builder
+	lines := 0
+	for i := 1; i < len(src); i++ {
+		r := src[i]
+		switch {
+		case backslash && r == '\\' && i+1 < len(src):
+			i++
+			value.WriteRune(unescapeRune(src[i]))
+			continue
+		case r == quote:
+			if i+1 < len(src) && src[i+1] == quote {
+				value.WriteRune(quote)
+				i++
+				continue
+			}
+			return value.String(), i + 1, lines, nil
+		case r == '\n':
+			lines++
+		}
+		value.WriteRune(r)
+	}
+	return "", 0, 0, fmt.Errorf("引号 %c 未闭合", quote)
+}
+
+// indexRunes 从 from 开始查找 pattern，返回其在 src 中的下标，找不到时返回 -1
+func indexRunes(src []rune, from int, pattern string) int {
+	p := []rune(pattern)
+	for i := from; i+len(p) <= len(src); i++ {
+		if string(src[i:i+len(p)]) == pattern {
+			return i
+		}
+	}
+	return -1
+}
+
+// unescapeRune 处理 MySQL 字符串中的反斜杠转义
+func unescapeRune(r rune) rune {
+	switch r {
+	case 'n':
+		return '\n'
+	case 't':
+		return '\t'
+	case 'r':
+		return '\r'
+	case '0':
+		return 0
+	default:
+		return r
+	}
+}
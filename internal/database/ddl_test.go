@@ -0,0 +1,259 @@
+package database
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestParseMySQLDDL(t *testing.T) {
+	ddl := "-- 用户表\n" +
+		"CREATE TABLE IF NOT EXISTS `users` (\n" +
+		"  `id` bigint NOT NULL AUTO_INCREMENT COMMENT '用户ID',\n" +
+		"  `email` varchar(255) NOT NULL COMMENT 'it''s email',\n" +
+		"  `name` varchar(100) CHARACTER SET utf8mb4 COLLATE utf8mb4_bin DEFAULT NULL,\n" +
+		"  `status` tinyint NOT NULL DEFAULT '1',\n" +
+		"  PRIMARY KEY (`id`),\n" +
+		"  UNIQUE KEY `uk_email` (`email`),\n" +
+		"  KEY `idx_name` (`name`(10))\n" +
+		") ENGINE=InnoDB COMMENT='用户表';\n" +
+		"CREATE TABLE orders (\n" +
+		"  id int NOT NULL AUTO_INCREMENT PRIMARY KEY,\n" +
+		"  user_id bigint NOT NULL,\n" +
+		"  price decimal(10,2) NOT NULL,\n" +
+		"  quantity int NOT NULL,\n" +
+		"  total decimal(12,2) GENERATED ALWAYS AS (price * quantity) STORED,\n" +
+		"  label varchar(20) AS (concat('#', id)),\n" +
+		"  CONSTRAINT fk_orders_user FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE\n" +
+		");\n" +
+		"ALTER TABLE orders ADD COLUMN remark text COMMENT '备注', ADD INDEX idx_user (user_id);\n" +
+		"INSERT INTO users VALUES (1, 'a;b', NULL, 1);\n"
+	
+	parser := newDDLParser("mysql")
+	if err := parser.Parse(ddl); err != nil {
+		t.Fatalf("解析 DDL 失败: %v", err)
+	}
+	tables := parser.Tables()
+	
+	if len(tables) != 2 {
+		t.Fatalf("期望解析出2个表，实际为 %d", len(tables))
+	}
+	
+	users := tables[0]
+	if users.Name != "users" || users.Comment != "用户表" {
+		t.Errorf("表信息不正确: %s (%s)", users.Name, users.Comment)
+	}
+	
+	expectedColumns := []Column{
+		{Name: "id", Type: "bigint", GoType: "int64", IsPrimaryKey: true, IsAutoIncr: true, Comment: "用户ID", FullType: "bigint"},
+		{Name: "email", Type: "varchar", GoType: "string", Comment: "it's email", FullType: "varchar(255)", Length: 255},
+		{Name: "name", Type: "varchar", GoType: "*string", Nullable: true, FullType: "varchar(100)", Length: 100, Charset: "utf8mb4", Collation: "utf8mb4_bin"},
+		{Name: "status", Type: "tinyint", GoType: "int", DefaultValue: "1", FullType: "tinyint"},
+	}
+	if !reflect.DeepEqual(users.Columns, expectedColumns) {
+		t.Errorf("列信息不正确:\n期望 %+v\n实际 %+v", expectedColumns, users.Columns)
+	}
+	
+	expectedIndexes := []Index{
+		{Name: "PRIMARY", Columns: []string{"id"}, IsUnique: true, IsPrimary: true},
+		{Name: "uk_email", Columns: []string{"email"}, IsUnique: true},
+		{Name: "idx_name", Columns: []string{"name"}},
+	}
+	if !reflect.DeepEqual(users.Indexes, expectedIndexes) {
+		t.Errorf("索引信息不正确:\n期望 %+v\n实际 %+v", expectedIndexes, users.Indexes)
+	}
+	
+	orders := tables[1]
+	if len(orders.Columns) != 7 || orders.Columns[6].Name != "remark" || orders.Columns[6].Comment != "备注" {
+		t.Errorf("ALTER TABLE 添加的列不正确: %+v", orders.Columns)
+	}
+	if !orders.Columns[0].IsPrimaryKey {
+		t.Error("期望 orders.id 为主键")
+	}
+	if price := orders.Columns[2]; price.Precision != 10 || price.Scale != 2 || price.Generated != "" {
+		t.Errorf("期望 orders.price 精度为 (10,2) 的普通列: %+v", price)
+	}
+	if total := orders.Columns[4]; total.Generated != "STORED" || total.GeneratedExpr != "price * quantity" {
+		t.Errorf("期望 orders.total 为存储生成列: %+v", total)
+	}
+	if label := orders.Columns[5]; label.Generated != "VIRTUAL" || label.GeneratedExpr != "concat ('#', id)" || label.Length != 20 {
+		t.Errorf("期望 orders.label 为虚拟生成列: %+v", label)
+	}
+	
+	expectedForeignKeys := []ForeignKey{
+		{Name: "fk_orders_user", Columns: []string{"user_id"}, RefTable: "users", RefColumns: []string{"id"}},
+	}
+	if !reflect.DeepEqual(orders.ForeignKeys, expectedForeignKeys) {
+		t.Errorf("外键信息不正确:\n期望 %+v\n实际 %+v", expectedForeignKeys, orders.ForeignKeys)
+	}
+	if len(orders.Indexes) != 2 || orders.Indexes[1].Name != "idx_user" {
+		t.Errorf("ALTER TABLE 添加的索引不正确: %+v", orders.Indexes)
+	}
+}
+
+func TestParsePostgresDDL(t *testing.T) {
+	ddl := `
+CREATE TABLE public.users (
+  id bigserial PRIMARY KEY,
+  email character varying(255) NOT NULL UNIQUE,
+  status varchar(20) DEFAULT 'active'::character varying,
+  created_at timestamp with time zone NOT NULL DEFAULT now()
+);
+COMMENT ON TABLE users IS '用户表';
+COMMENT ON COLUMN public.users.email IS '邮箱';
+
+CREATE TABLE orders (
+  id integer GENERATED ALWAYS AS IDENTITY,
+  user_id bigint NOT NULL REFERENCES users,
+  total numeric(10,2),
+  CONSTRAINT orders_pk PRIMARY KEY (id)
+);
+CREATE UNIQUE INDEX orders_lower ON orders (lower(total::text));
+CREATE INDEX orders_partial ON orders (user_id) WHERE total > 0;
+CREATE INDEX orders_user_idx ON orders USING btree (user_id, total DESC);
+CREATE FUNCTION touch() RETURNS trigger AS $$ BEGIN NEW.total := 0; RETURN NEW; END; $$ LANGUAGE plpgsql;
+`
+
+	parser := newDDLParser("postgres")
+	if err := parser.Parse(ddl); err != nil {
+		t.Fatalf("解析 DDL 失败: %v", err)
+	}
+	tables := parser.Tables()
+	
+	if len(tables) != 2 {
+		t.Fatalf("期望解析出2个表，实际为 %d", len(tables))
+	}
+	
+	users := tables[0]
+	if users.Comment != "用户表" {
+		t.Errorf("期望表注释为 '用户表'，实际为 '%s'", users.Comment)
+	}
+	
+	expectedColumns := []Column{
+		{Name: "id", Type: "bigint", GoType: "int64", IsPrimaryKey: true, IsAutoIncr: true, DefaultValue: "nextval('users_id_seq'::regclass)", Sequence: "users_id_seq"},
+		{Name: "email", Type: "character varying", GoType: "string", Comment: "邮箱", Length: 255},
+		{Name: "status", Type: "character varying", GoType: "*string", Nullable: true, DefaultValue: "'active'::character varying", Length: 20},
+		{Name: "created_at", Type: "timestamp with time zone", GoType: "time.Time", DefaultValue: "now()"},
+	}
+	if !reflect.DeepEqual(users.Columns, expectedColumns) {
+		t.Errorf("列信息不正确:\n期望 %+v\n实际 %+v", expectedColumns, users.Columns)
+	}
+	
+	expectedIndexes := []Index{
+		{Name: "users_pkey", Columns: []string{"id"}, IsUnique: true, IsPrimary: true},
+		{Name: "users_email_key", Columns: []string{"email"}, IsUnique: true},
+	}
+	if !reflect.DeepEqual(users.Indexes, expectedIndexes) {
+		t.Errorf("索引信息不正确:\n期望 %+v\n实际 %+v", expectedIndexes, users.Indexes)
+	}
+	
+	orders := tables[1]
+	if id := orders.Columns[0]; !id.IsPrimaryKey || !id.IsAutoIncr || id.Identity != "ALWAYS" || id.Sequence != "orders_id_seq" {
+		t.Errorf("期望 orders.id 为标识列主键: %+v", id)
+	}
+	
+	// 表达式索引和部分索引会被跳过
+	expectedIndexes = []Index{
+		{Name: "orders_pk", Columns: []string{"id"}, IsUnique: true, IsPrimary: true},
+		{Name: "orders_user_idx", Columns: []string{"user_id", "total"}},
+	}
+	if !reflect.DeepEqual(orders.Indexes, expectedIndexes) {
+		t.Errorf("索引信息不正确:\n期望 %+v\n实际 %+v", expectedIndexes, orders.Indexes)
+	}
+	
+	expectedForeignKeys := []ForeignKey{
+		{Name: "orders_user_id_fkey", Columns: []string{"user_id"}, RefTable: "users", RefColumns: []string{"id"}},
+	}
+	if !reflect.DeepEqual(orders.ForeignKeys, expectedForeignKeys) {
+		t.Errorf("外键信息不正确:\n期望 %+v\n实际 %+v", expectedForeignKeys, orders.ForeignKeys)
+	}
+}
+
+func TestParsePostgresEnumDDL(t *testing.T) {
+	ddl := `
+CREATE TYPE public.order_status AS ENUM ('pending', 'paid', 'it''s shipped');
+CREATE TYPE point3 AS (x int, y int, z int);
+CREATE TABLE orders (
+  id serial PRIMARY KEY,
+  status order_status NOT NULL DEFAULT 'pending',
+  prev_status order_status,
+  history order_status[],
+  amounts integer ARRAY
+);
+`
+	
+	parser := newDDLParser("postgres")
+	if err := parser.Parse(ddl); err != nil {
+		t.Fatalf("解析 DDL 失败: %v", err)
+	}
+	tables := parser.Tables()
+	if len(tables) != 1 {
+		t.Fatalf("期望解析出1个表，实际为 %d", len(tables))
+	}
+	
+	values := []string{"pending", "paid", "it's shipped"}
+	expectedColumns := []Column{
+		{Name: "status", Type: "USER-DEFINED", GoType: "string", DefaultValue: "'pending'", EnumName: "order_status", EnumValues: values},
+		{Name: "prev_status", Type: "USER-DEFINED", GoType: "*string", Nullable: true, EnumName: "order_status", EnumValues: values},
+	}
+	if !reflect.DeepEqual(tables[0].Columns[1:3], expectedColumns) {
+		t.Errorf("列信息不正确:\n期望 %+v\n实际 %+v", expectedColumns, tables[0].Columns[1:3])
+	}
+	
+	// 数组列的完整类型使用 udt_name 的写法记录元素类型
+	expectedColumns = []Column{
+		{Name: "history", Type: "ARRAY", GoType: "pq.StringArray", Nullable: true, FullType: "order_status[]"},
+		{Name: "amounts", Type: "ARRAY", GoType: "pq.Int32Array", Nullable: true, FullType: "int4[]"},
+	}
+	if !reflect.DeepEqual(tables[0].Columns[3:], expectedColumns) {
+		t.Errorf("数组列信息不正确:\n期望 %+v\n实际 %+v", expectedColumns, tables[0].Columns[3:])
+	}
+}
+
+func TestDDLConnect(t *testing.T) {
+	tmpDir := t.TempDir()
+	schemaFile := filepath.Join(tmpDir, "schema.sql")
+	alterFile := filepath.Join(tmpDir, "alter.sql")
+	
+	if err := os.WriteFile(schemaFile, []byte("CREATE TABLE users (id int PRIMARY KEY);"), 0644); err != nil {
+		t.Fatalf("创建 DDL 文件失败: %v", err)
+	}
+	if err := os.WriteFile(alterFile, []byte("ALTER TABLE users ADD name varchar(50);"), 0644); err != nil {
+		t.Fatalf("创建 DDL 文件失败: %v", err)
+	}
+	
+	db := NewDDL([]string{schemaFile, alterFile}, "")
+	if err := db.Connect(context.Background()); err != nil {
+		t.Fatalf("解析 DDL 文件失败: %v", err)
+	}
+	
+	columns, err := db.GetTableColumns(context.Background(), "users")
+	if err != nil {
+		t.Fatalf("获取列信息失败: %v", err)
+	}
+	if len(columns) != 2 || columns[1].GoType != "*string" {
+		t.Errorf("列信息不正确: %+v", columns)
+	}
+	
+	if _, err := db.GetTableColumns(context.Background(), "missing"); err == nil {
+		t.Error("期望表不存在时返回错误")
+	}
+	
+	if err := NewDDL([]string{filepath.Join(tmpDir, "missing.sql")}, "mysql").Connect(context.Background()); err == nil {
+		t.Error("期望文件不存在时返回错误")
+	}
+}
+
+func TestParseDDLInvalidReferences(t *testing.T) {
+	// 列级和表级外键的语法错误都应返回
+	for _, ddl := range []string{
+		"CREATE TABLE orders (id int PRIMARY KEY, user_id int REFERENCES (id));",
+		"CREATE TABLE orders (id int PRIMARY KEY, user_id int, FOREIGN KEY (user_id) REFERENCES (id));",
+	} {
+		if err := newDDLParser("postgres").Parse(ddl); err == nil {
+			t.Errorf("期望外键语法错误时返回错误: %s", ddl)
+		}
+	}
+}
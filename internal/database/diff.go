@@ -0,0 +1,220 @@
+package database
+
+import (
+	"sort"
+	"strings"
+)
+
+// SchemaDiff 两份表结构之间的差异
+type SchemaDiff struct {
+	AddedTables   []string    `json:"added_tables,omitempty"`   // 新增的表
+	RemovedTables []string    `json:"removed_tables,omitempty"` // 删除的表
+	ChangedTables []TableDiff `json:"changed_tables,omitempty"` // 结构发生变化的表
+}
+
+// TableDiff 单个表的结构差异
+type TableDiff struct {
+	Name           string            `json:"name"`                      // 表名
+	AddedColumns   []string          `json:"added_columns,omitempty"`   // 新增的列
+	DroppedColumns []string          `json:"dropped_columns,omitempty"` // 删除的列
+	ColumnChanges  []ColumnChange    `json:"column_changes,omitempty"`  // 列类型或可空性变化
+	PrimaryKey     *PrimaryKeyChange `json:"primary_key,omitempty"`     // 主键变化
+	AddedIndexes   []string          `json:"added_indexes,omitempty"`   // 新增的索引（定义变化的索引同时出现在新增和删除中）
+	DroppedIndexes []string          `json:"dropped_indexes,omitempty"` // 删除的索引
+	
+	AddedForeignKeys   []string `json:"added_foreign_keys,omitempty"`   // 新增的外键（定义变化的外键同时出现在新增和删除中）
+	DroppedForeignKeys []string `json:"dropped_foreign_keys,omitempty"` // 删除的外键
+}
+
+// ColumnChange 列属性变化
+type ColumnChange struct {
+	Column string `json:"column"` // 列名
+	Kind   string `json:"kind"`   // 变化类型：type, nullable
+	From   string `json:"from"`   // 原值
+	To     string `json:"to"`     // 新值
+}
+
+// PrimaryKeyChange 主键列变化
+type PrimaryKeyChange struct {
+	From []string `json:"from"` // 原主键列
+	To   []string `json:"to"`   // 新主键列
+}
+
+// HasChanges 判断是否存在差异
+func (d SchemaDiff) HasChanges() bool {
+	return len(d.AddedTables) > 0 || len(d.RemovedTables) > 0 || len(d.ChangedTables) > 0
+}
+
+// DiffTables 比较两份表结构，from 为基准，to 为目标
+func DiffTables(from, to []Table) SchemaDiff {
+	var diff SchemaDiff
+	
+	fromMap := make(map[string]Table, len(from))
+	for _, table := range from {
+		fromMap[table.QualifiedName()] = table
+	}
+	toMap := make(map[string]Table, len(to))
+	for _, table := range to {
+		toMap[table.QualifiedName()] = table
+	}
+	
+	for _, table := range to {
+		if _, ok := fromMap[table.QualifiedName()]; !ok {
+			diff.AddedTables = append(diff.AddedTables, table.QualifiedName())
+		}
+	}
+	for _, table := range from {
+		target, ok := toMap[table.QualifiedName()]
+		if !ok {
+			diff.RemovedTables = append(diff.RemovedTables, table.QualifiedName())
+			continue
+		}
+		if tableDiff, changed := diffTable(table, target); changed {
+			diff.ChangedTables = append(diff.ChangedTables, tableDiff)
+		}
+	}
+	
+	sort.Strings(diff.AddedTables)
+	sort.Strings(diff.RemovedTables)
+	sort.Slice(diff.ChangedTables, func(i, j int) bool {
+		return diff.ChangedTables[i].Name < diff.ChangedTables[j].Name
+	})
+	
+	return diff
+}
+
+// diffTable 比较同名表的列、主键、索引和外键
+func diffTable(from, to Table) (TableDiff, bool) {
+	diff := TableDiff{Name: from.QualifiedName()}
+	
+	fromColumns := make(map[string]Column, len(from.Columns))
+	for _, col := range from.Columns {
+		fromColumns[col.Name] = col
+	}
+	toColumns := make(map[string]Column, len(to.Columns))
+	for _, col := range to.Columns {
+		toColumns[col.Name] = col
+	}
+	
+	for _, col := range to.Columns {
+		if _, ok := fromColumns[col.Name]; !ok {
+			diff.AddedColumns = append(diff.AddedColumns, col.Name)
+		}
+	}
+	for _, col := range from.Columns {
+		target, ok := toColumns[col.Name]
+		if !ok {
+			diff.DroppedColumns = append(diff.DroppedColumns, col.Name)
+			continue
+		}
+		
+		if fromType, toType := columnTypes(col, target); !strings.EqualFold(fromType, toType) {
+			diff.ColumnChanges = append(diff.ColumnChanges, ColumnChange{
+				Column: col.Name,
+				Kind:   "type",
+				From:   fromType,
+				To:     toType,
+			})
+		}
+		if col.Nullable != target.Nullable {
+			diff.ColumnChanges = append(diff.ColumnChanges, ColumnChange{
+				Column: col.Name,
+				Kind:   "nullable",
+				From:   nullableText(col.Nullable),
+				To:     nullableText(target.Nullable),
+			})
+		}
+	}
+	
+	fromKey := primaryKeyColumns(from)
+	toKey := primaryKeyColumns(to)
+	if strings.Join(fromKey, ",") != strings.Join(toKey, ",") {
+		diff.PrimaryKey = &PrimaryKeyChange{From: fromKey, To: toKey}
+	}
+	
+	fromIndexes := make(map[string]Index, len(from.Indexes))
+	for _, index := range from.Indexes {
+		fromIndexes[index.Name] = index
+	}
+	toIndexes := make(map[string]Index, len(to.Indexes))
+	for _, index := range to.Indexes {
+		toIndexes[index.Name] = index
+	}
+	
+	// 主键索引的变化已体现在主键差异中
+	for _, index := range to.Indexes {
+		if old, ok := fromIndexes[index.Name]; !index.IsPrimary && (!ok || !sameIndex(old, index)) {
+			diff.AddedIndexes = append(diff.AddedIndexes, index.Name)
+		}
+	}
+	for _, index := range from.Indexes {
+		if target, ok := toIndexes[index.Name]; !index.IsPrimary && (!ok || !sameIndex(index, target)) {
+			diff.DroppedIndexes = append(diff.DroppedIndexes, index.Name)
+		}
+	}
+	
+	fromForeignKeys := make(map[string]ForeignKey, len(from.ForeignKeys))
+	for _, fk := range from.ForeignKeys {
+		fromForeignKeys[fk.Name] = fk
+	}
+	toForeignKeys := make(map[string]ForeignKey, len(to.ForeignKeys))
+	for _, fk := range to.ForeignKeys {
+		toForeignKeys[fk.Name] = fk
+	}
+	
+	for _, fk := range to.ForeignKeys {
+		if old, ok := fromForeignKeys[fk.Name]; !ok || !sameForeignKey(old, fk) {
+			diff.AddedForeignKeys = append(diff.AddedForeignKeys, fk.Name)
+		}
+	}
+	for _, fk := range from.ForeignKeys {
+		if target, ok := toForeignKeys[fk.Name]; !ok || !sameForeignKey(fk, target) {
+			diff.DroppedForeignKeys = append(diff.DroppedForeignKeys, fk.Name)
+		}
+	}
+	
+	changed := len(diff.AddedColumns) > 0 || len(diff.DroppedColumns) > 0 ||
+		len(diff.ColumnChanges) > 0 || diff.PrimaryKey != nil ||
+		len(diff.AddedIndexes) > 0 || len(diff.DroppedIndexes) > 0 ||
+		len(diff.AddedForeignKeys) > 0 || len(diff.DroppedForeignKeys) > 0
+	return diff, changed
+}
+
+// primaryKeyColumns 返回表的主键列，按列定义顺序排列
+func primaryKeyColumns(table Table) []string {
+	var columns []string
+	for _, col := range table.Columns {
+		if col.IsPrimaryKey {
+			columns = append(columns, col.Name)
+		}
+	}
+	return columns
+}
+
+// columnTypes 返回用于比较的列类型，两侧都有完整类型时比较完整类型，否则比较带长度和精度的类型
+func columnTypes(from, to Column) (string, string) {
+	if from.FullType != "" && to.FullType != "" {
+		return from.FullType, to.FullType
+	}
+	return from.SizedType(), to.SizedType()
+}
+
+// sameIndex 判断两个索引的定义是否一致
+func sameIndex(a, b Index) bool {
+	return a.IsUnique == b.IsUnique && a.IsPrimary == b.IsPrimary &&
+		strings.Join(a.Columns, ",") == strings.Join(b.Columns, ",")
+}
+
+// sameForeignKey 判断两个外键的定义是否一致
+func sameForeignKey(a, b ForeignKey) bool {
+	return a.RefTable == b.RefTable && strings.Join(a.Columns, ",") == strings.Join(b.Columns, ",") &&
+		strings.Join(a.RefColumns, ",") == strings.Join(b.RefColumns, ",")
+}
+
+// nullableText 返回可空性的文字描述
+func nullableText(nullable bool) string {
+	if nullable {
+		return "NULL"
+	}
+	return "NOT NULL"
+}
@@ -0,0 +1,106 @@
+package database
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiffTables(t *testing.T) {
+	from := []Table{
+		{
+			Name: "users",
+			Columns: []Column{
+				{Name: "id", Type: "int", IsPrimaryKey: true},
+				{Name: "email", Type: "varchar"},
+				{Name: "age", Type: "int", Nullable: true},
+			},
+		},
+		{Name: "legacy", Columns: []Column{{Name: "id", Type: "int"}}},
+		{Name: "orders", Columns: []Column{{Name: "id", Type: "int", IsPrimaryKey: true}}},
+	}
+	to := []Table{
+		{
+			Name: "users",
+			Columns: []Column{
+				{Name: "id", Type: "INT", IsPrimaryKey: true},
+				{Name: "tenant_id", Type: "int", IsPrimaryKey: true},
+				{Name: "email", Type: "text", Nullable: true},
+			},
+			Indexes: []Index{{Name: "uk_email", Columns: []string{"email"}, IsUnique: true}},
+		},
+		{Name: "orders", Columns: []Column{{Name: "id", Type: "int", IsPrimaryKey: true}}},
+		{Name: "audit_logs", Columns: []Column{{Name: "id", Type: "int"}}},
+	}
+	
+	diff := DiffTables(from, to)
+	expected := SchemaDiff{
+		AddedTables:   []string{"audit_logs"},
+		RemovedTables: []string{"legacy"},
+		ChangedTables: []TableDiff{
+			{
+				Name:           "users",
+				AddedColumns:   []string{"tenant_id"},
+				DroppedColumns: []string{"age"},
+				ColumnChanges: []ColumnChange{
+					{Column: "email", Kind: "type", From: "varchar", To: "text"},
+					{Column: "email", Kind: "nullable", From: "NOT NULL", To: "NULL"},
+				},
+				PrimaryKey:   &PrimaryKeyChange{From: []string{"id"}, To: []string{"id", "tenant_id"}},
+				AddedIndexes: []string{"uk_email"},
+			},
+		},
+	}
+	if !reflect.DeepEqual(diff, expected) {
+		t.Errorf("差异不正确:\n期望 %+v\n实际 %+v", expected, diff)
+	}
+	if !diff.HasChanges() {
+		t.Error("期望存在差异")
+	}
+	
+	if DiffTables(from, from).HasChanges() {
+		t.Error("相同表结构不应存在差异")
+	}
+}
+
+func TestDiffForeignKeys(t *testing.T) {
+	from := []Table{{Name: "orders", ForeignKeys: []ForeignKey{
+		{Name: "fk_orders_user", Columns: []string{"user_id"}, RefTable: "users", RefColumns: []string{"id"}},
+		{Name: "fk_orders_shop", Columns: []string{"shop_id"}, RefTable: "shops", RefColumns: []string{"id"}},
+	}}}
+	to := []Table{{Name: "orders", ForeignKeys: []ForeignKey{
+		{Name: "fk_orders_user", Columns: []string{"user_id"}, RefTable: "accounts", RefColumns: []string{"id"}},
+		{Name: "fk_orders_coupon", Columns: []string{"coupon_id"}, RefTable: "coupons", RefColumns: []string{"id"}},
+	}}}
+	
+	diff := DiffTables(from, to)
+	if len(diff.ChangedTables) != 1 {
+		t.Fatalf("期望 orders 表存在差异，实际为 %+v", diff)
+	}
+	changed := diff.ChangedTables[0]
+	if !reflect.DeepEqual(changed.AddedForeignKeys, []string{"fk_orders_user", "fk_orders_coupon"}) ||
+		!reflect.DeepEqual(changed.DroppedForeignKeys, []string{"fk_orders_user", "fk_orders_shop"}) {
+		t.Errorf("外键差异不正确: %+v", changed)
+	}
+}
+
+func TestDiffColumnSize(t *testing.T) {
+	from := []Table{{Name: "orders", Columns: []Column{
+		{Name: "code", Type: "character varying", Length: 50},
+		{Name: "amount", Type: "numeric", Precision: 10, Scale: 2},
+		{Name: "quantity", Type: "integer", Precision: 32},
+	}}}
+	to := []Table{{Name: "orders", Columns: []Column{
+		{Name: "code", Type: "character varying", Length: 100},
+		{Name: "amount", Type: "numeric", Precision: 12, Scale: 2},
+		{Name: "quantity", Type: "integer", Precision: 32},
+	}}}
+	
+	expected := []ColumnChange{
+		{Column: "code", Kind: "type", From: "character varying(50)", To: "character varying(100)"},
+		{Column: "amount", Kind: "type", From: "numeric(10,2)", To: "numeric(12,2)"},
+	}
+	diff := DiffTables(from, to)
+	if len(diff.ChangedTables) != 1 || !reflect.DeepEqual(diff.ChangedTables[0].ColumnChanges, expected) {
+		t.Errorf("列类型差异不正确:\n期望 %+v\n实际 %+v", expected, diff.ChangedTables)
+	}
+}
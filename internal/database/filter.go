@@ -0,0 +1,60 @@
+package database
+
+import (
+	"strings"
+)
+
+// TableFilter 表过滤规则，数据源在读取列、索引和外键之前按规则筛选表，未选中的表不会被读取
+type TableFilter struct {
+	Include []string // 包含的表，可以是 schema.table 形式，为空时包含所有表
+	Exclude []string // 排除的表，支持 * 通配符
+	Prefix  string   // 表名前缀
+}
+
+// Match 判断表是否被选中，只使用表的 Schema 和 Name
+func (f TableFilter) Match(table Table) bool {
+	// 检查包含列表
+	if len(f.Include) > 0 {
+		found := false
+		for _, include := range f.Include {
+			if table.Name == include || table.QualifiedName() == include {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	
+	// 检查排除列表
+	for _, exclude := range f.Exclude {
+		if strings.Contains(exclude, "*") {
+			// 支持通配符
+			pattern := strings.ReplaceAll(exclude, "*", "")
+			if strings.Contains(table.Name, pattern) {
+				return false
+			}
+		} else if table.Name == exclude || table.QualifiedName() == exclude {
+			return false
+		}
+	}
+	
+	// 检查前缀
+	if f.Prefix != "" && !strings.HasPrefix(table.Name, f.Prefix) {
+		return false
+	}
+	
+	return true
+}
+
+// Apply 返回被选中的表，保持原有顺序
+func (f TableFilter) Apply(tables []Table) []Table {
+	var filtered []Table
+	for _, table := range tables {
+		if f.Match(table) {
+			filtered = append(filtered, table)
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,158 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync/atomic"
+)
+
+// migrationsDBSeq 内存数据库序号，保证同一进程中的多个迁移数据源互不影响
+var migrationsDBSeq atomic.Int64
+
+// Migrations 将迁移目录中的 up 脚本依次应用到内存 SQLite 数据库，再读取表结构
+type Migrations struct {
+	SQLite
+	Dir string // 迁移文件目录
+}
+
+// migrationFile 迁移文件
+type migrationFile struct {
+	Version uint64
+	Path    string
+}
+
+// NewMigrations 创建迁移目录数据源
+func NewMigrations(dir string) *Migrations {
+	return &Migrations{
+		// 读取表结构时会在遍历结果集的同时发起查询，需要多个连接访问同一个内存数据库，因此使用具名的共享缓存数据库
+		SQLite: SQLite{DSN: fmt.Sprintf("file:migrations_%d_%d?mode=memory&cache=shared", os.Getpid(), migrationsDBSeq.Add(1))},
+		Dir:    dir,
+	}
+}
+
+func (m *Migrations) Connect(ctx context.Context) error {
+	files, err := loadMigrationFiles(m.Dir)
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		return fmt.Errorf("迁移目录 %s 中没有找到迁移文件", m.Dir)
+	}
+	
+	if err := m.SQLite.Connect(ctx); err != nil {
+		return err
+	}
+	
+	// 应用失败时关闭内存数据库：最后一个连接关闭后共享缓存数据库随之销毁，重试时不会看到只应用了一部分的表结构
+	if err := m.applyMigrations(ctx, files); err != nil {
+		m.db.Close()
+		m.db = nil
+		return err
+	}
+	
+	return nil
+}
+
+// applyMigrations 依次执行迁移文件的 up 脚本
+func (m *Migrations) applyMigrations(ctx context.Context, files []migrationFile) error {
+	for _, file := range files {
+		content, err := os.ReadFile(file.Path)
+		if err != nil {
+			return fmt.Errorf("读取迁移文件 %s 失败: %w", file.Path, err)
+		}
+		
+		if _, err := m.db.ExecContext(ctx, migrationUpSQL(string(content))); err != nil {
+			return fmt.Errorf("应用迁移文件 %s 失败: %w", filepath.Base(file.Path), err)
+		}
+	}
+	
+	return nil
+}
+
+// loadMigrationFiles 读取迁移目录中的 up 脚本并按版本号排序。
+// 支持 golang-migrate（1_init.up.sql）、goose（20240101120000_init.sql）和 Flyway（V1__init.sql）的命名方式，
+// down 脚本和没有版本号前缀的文件会被忽略。
+func loadMigrationFiles(dir string) ([]migrationFile, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("读取迁移目录失败: %w", err)
+	}
+	
+	var files []migrationFile
+	seen := make(map[uint64]string)
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".sql") || strings.HasSuffix(name, ".down.sql") {
+			continue
+		}
+		
+		version, ok := parseMigrationVersion(name)
+		if !ok {
+			continue
+		}
+		if other, exists := seen[version]; exists {
+			return nil, fmt.Errorf("迁移文件 %s 与 %s 的版本号重复", name, other)
+		}
+		seen[version] = name
+		
+		files = append(files, migrationFile{Version: version, Path: filepath.Join(dir, name)})
+	}
+	
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].Version < files[j].Version
+	})
+	
+	return files, nil
+}
+
+// parseMigrationVersion 解析文件名开头的数字版本号，允许 Flyway 风格的 V 前缀
+func parseMigrationVersion(name string) (uint64, bool) {
+	name = strings.TrimPrefix(strings.TrimPrefix(name, "V"), "v")
+	end := 0
+	for end < len(name) && name[end] >= '0' && name[end] <= '9' {
+		end++
+	}
+	if end == 0 {
+		return 0, false
+	}
+	
+	version, err := strconv.ParseUint(name[:end], 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return version, true
+}
+
+// migrationUpSQL 提取 goose 格式迁移文件中的 Up 部分，其他格式原样返回
+func migrationUpSQL(content string) string {
+	lines := strings.Split(content, "\n")
+	var up []string
+	goose := false
+	inUp := false
+	for _, line := range lines {
+		directive := strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(directive, "-- +goose Up"), strings.HasPrefix(directive, "-- +migrate Up"):
+			goose = true
+			inUp = true
+			continue
+		case strings.HasPrefix(directive, "-- +goose Down"), strings.HasPrefix(directive, "-- +migrate Down"):
+			goose = true
+			inUp = false
+			continue
+		}
+		if inUp {
+			up = append(up, line)
+		}
+	}
+	
+	if !goose {
+		return content
+	}
+	return strings.Join(up, "\n")
+}
@@ -0,0 +1,107 @@
+package database
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestMigrationsConnect(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"0002_orders.up.sql":   "CREATE TABLE orders (id INTEGER PRIMARY KEY, user_id INTEGER NOT NULL REFERENCES users(id));",
+		"0002_orders.down.sql": "DROP TABLE orders;",
+		"0001_users.up.sql":    "CREATE TABLE users (id INTEGER PRIMARY KEY, email TEXT NOT NULL);",
+		"0001_users.down.sql":  "DROP TABLE users;",
+		"10_add_name.sql":      "-- +goose Up\nALTER TABLE users ADD COLUMN name TEXT;\n-- +goose Down\nALTER TABLE users DROP COLUMN name;\n",
+		"README.md":            "迁移说明",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("创建迁移文件失败: %v", err)
+		}
+	}
+	
+	db := NewMigrations(dir)
+	if err := db.Connect(context.Background()); err != nil {
+		t.Fatalf("应用迁移失败: %v", err)
+	}
+	defer db.Close()
+	
+	tables, err := db.GetTables(context.Background(), TableFilter{})
+	if err != nil {
+		t.Fatalf("获取表信息失败: %v", err)
+	}
+	if len(tables) != 2 || tables[0].Name != "orders" || tables[1].Name != "users" {
+		t.Fatalf("表信息不正确: %+v", tables)
+	}
+	
+	users := tables[1]
+	if len(users.Columns) != 3 || users.Columns[2].Name != "name" {
+		t.Errorf("期望 goose 迁移添加 name 列，实际为 %+v", users.Columns)
+	}
+	if len(tables[0].ForeignKeys) != 1 || tables[0].ForeignKeys[0].RefTable != "users" {
+		t.Errorf("外键信息不正确: %+v", tables[0].ForeignKeys)
+	}
+}
+
+func TestMigrationsConnectRetry(t *testing.T) {
+	dir := t.TempDir()
+	broken := filepath.Join(dir, "0002_orders.up.sql")
+	for path, content := range map[string]string{
+		filepath.Join(dir, "0001_users.up.sql"): "CREATE TABLE users (id INTEGER PRIMARY KEY);",
+		broken:                                  "CREATE TABLE orders (id INTEGER PRIMARY KEY,);",
+	} {
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("创建迁移文件失败: %v", err)
+		}
+	}
+	
+	db := NewMigrations(dir)
+	if err := db.Connect(context.Background()); err == nil {
+		t.Fatal("期望迁移文件有误时连接失败")
+	}
+	
+	// 重试时从空数据库重新应用，不会因为上次已创建的表而失败
+	if err := os.WriteFile(broken, []byte("CREATE TABLE orders (id INTEGER PRIMARY KEY);"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Connect(context.Background()); err != nil {
+		t.Fatalf("期望重试成功，实际为 %v", err)
+	}
+	defer db.Close()
+	
+	tables, err := db.GetTables(context.Background(), TableFilter{})
+	if err != nil || len(tables) != 2 {
+		t.Errorf("期望重试后读取 2 个表，实际为 %d, %v", len(tables), err)
+	}
+}
+
+func TestLoadMigrationFiles(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"V2__b.sql", "V10__c.sql", "V1__a.sql"} {
+		if err := os.WriteFile(filepath.Join(dir, name), nil, 0644); err != nil {
+			t.Fatalf("创建迁移文件失败: %v", err)
+		}
+	}
+	
+	files, err := loadMigrationFiles(dir)
+	if err != nil {
+		t.Fatalf("读取迁移文件失败: %v", err)
+	}
+	var versions []uint64
+	for _, file := range files {
+		versions = append(versions, file.Version)
+	}
+	if len(versions) != 3 || versions[0] != 1 || versions[1] != 2 || versions[2] != 10 {
+		t.Errorf("期望按版本号排序为 [1 2 10]，实际为 %v", versions)
+	}
+	
+	if err := os.WriteFile(filepath.Join(dir, "2_dup.up.sql"), nil, 0644); err != nil {
+		t.Fatalf("创建迁移文件失败: %v", err)
+	}
+	if _, err := loadMigrationFiles(dir); err == nil {
+		t.Error("期望版本号重复时返回错误")
+	}
+}
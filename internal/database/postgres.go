@@ -1,25 +1,49 @@
 package database
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"strings"
+	
+	"github.com/lib/pq"
 )
 
 // PostgreSQL 实现
 type PostgreSQL struct {
-	DSN string
-	db  *sql.DB
+	DSN     string
+	Schemas []string // 要读取的 schema，为空时读取 public
+	db      *sql.DB
+	enums   map[string][]string // 枚举类型名到可选值的映射，首次读取列信息时加载
+}
+
+// schemas 返回要读取的 schema 列表
+func (p *PostgreSQL) schemas() []string {
+	if len(p.Schemas) == 0 {
+		return []string{"public"}
+	}
+	return p.Schemas
+}
+
+// splitTableName 拆分 schema.table 形式的表名，不带 schema 时使用 public
+func (p *PostgreSQL) splitTableName(tableName string) (string, string) {
+	schema, name := splitTableName(tableName)
+	if schema == "" {
+		schema = "public"
+	}
+	return schema, name
 }
 
-func (p *PostgreSQL) Connect() error {
+func (p *PostgreSQL) Connect(ctx context.Context) error {
 	db, err := sql.Open("postgres", p.DSN)
 	if err != nil {
-		return fmt.Errorf("连接 PostgreSQL 失败: %w", err)
+		return fmt.Errorf("连接 PostgreSQL 失败: %w", RedactError(err, p.DSN))
 	}
 	
-	if err := db.Ping(); err != nil {
-		return fmt.Errorf("ping PostgreSQL 失败: %w", err)
+	// 连接失败时关闭连接池，避免重试时泄漏
+	if err := db.PingContext(ctx); err != nil {
+		db.Close()
+		return fmt.Errorf("ping PostgreSQL 失败: %w", RedactError(err, p.DSN))
 	}
 	
 	p.db = db
@@ -33,112 +57,563 @@ func (p *PostgreSQL) Close() error {
 	return nil
 }
 
-func (p *PostgreSQL) GetTables() ([]Table, error) {
+// GetTables 先读取表清单并按 filter 筛选，再为选中的表批量查询列、索引和外键，避免逐表查询
+func (p *PostgreSQL) GetTables(ctx context.Context, filter TableFilter) ([]Table, error) {
+	// information_schema.tables 不包含物化视图，从 pg_matviews 中补充
 	query := `
 		SELECT 
-			table_name,
+			t.table_schema,
+			t.table_name,
+			CASE WHEN t.table_type = 'VIEW' THEN 'view' ELSE '' END as kind,
 			COALESCE(obj_description(c.oid), '') as table_comment
 		FROM 
 			information_schema.tables t
+		JOIN 
+			pg_namespace n ON n.nspname = t.table_schema
 		LEFT JOIN 
-			pg_class c ON c.relname = t.table_name
+			pg_class c ON c.relname = t.table_name AND c.relnamespace = n.oid
+		WHERE 
+			t.table_schema = ANY($1)
+			AND t.table_type IN ('BASE TABLE', 'VIEW')
+		UNION ALL
+		SELECT 
+			n.nspname,
+			c.relname,
+			'materialized_view' as kind,
+			COALESCE(obj_description(c.oid), '') as table_comment
+		FROM 
+			pg_class c
+		JOIN 
+			pg_namespace n ON n.oid = c.relnamespace
 		WHERE 
-			t.table_schema = 'public'
-			AND t.table_type = 'BASE TABLE'
+			n.nspname = ANY($1)
+			AND c.relkind = 'm'
 		ORDER BY 
-			t.table_name
+			1, 2
 	`
 	
-	rows, err := p.db.Query(query)
+	rows, err := p.db.QueryContext(ctx, query, pq.Array(p.schemas()))
 	if err != nil {
 		return nil, fmt.Errorf("查询表信息失败: %w", err)
 	}
 	defer rows.Close()
 	
 	var tables []Table
+	var schemas []string
 	for rows.Next() {
 		var table Table
-		if err := rows.Scan(&table.Name, &table.Comment); err != nil {
+		var schema string
+		if err := rows.Scan(&schema, &table.Name, &table.Kind, &table.Comment); err != nil {
 			return nil, fmt.Errorf("扫描表信息失败: %w", err)
 		}
 		
-		// 获取列信息
-		columns, err := p.GetTableColumns(table.Name)
-		if err != nil {
-			return nil, fmt.Errorf("获取表 %s 的列信息失败: %w", table.Name, err)
+		// 只在配置了 schemas 时记录 schema，保持默认读取 public 时的表名不变
+		if len(p.Schemas) > 0 {
+			table.Schema = schema
+		}
+		
+		if filter.Match(table) {
+			tables = append(tables, table)
+			schemas = append(schemas, schema)
 		}
-		table.Columns = columns
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("读取表信息失败: %w", err)
+	}
+	rows.Close()
+	
+	if len(tables) == 0 {
+		return nil, nil
+	}
+	
+	names := make([]string, len(tables))
+	for i, table := range tables {
+		names[i] = table.Name
+	}
+	
+	columns, err := p.columns(ctx, schemas, names)
+	if err != nil {
+		return nil, fmt.Errorf("获取列信息失败: %w", err)
+	}
+	indexes, err := p.indexes(ctx, schemas, names)
+	if err != nil {
+		return nil, fmt.Errorf("获取索引信息失败: %w", err)
+	}
+	foreignKeys, err := p.foreignKeys(ctx, schemas, names)
+	if err != nil {
+		return nil, fmt.Errorf("获取外键信息失败: %w", err)
+	}
+	
+	for i := range tables {
+		key := tables[i].QualifiedName()
+		tables[i].Columns = columns[key]
+		tables[i].Indexes = indexes[key]
+		tables[i].ForeignKeys = foreignKeys[key]
 		
-		tables = append(tables, table)
+		// information_schema.columns 不包含物化视图的列
+		if len(tables[i].Columns) == 0 {
+			enums, err := p.enumTypes(ctx)
+			if err != nil {
+				return nil, err
+			}
+			if tables[i].Columns, err = p.relationColumns(ctx, schemas[i], tables[i].Name, enums); err != nil {
+				return nil, fmt.Errorf("获取表 %s 的列信息失败: %w", key, err)
+			}
+		}
 	}
 	
 	return tables, nil
 }
 
-func (p *PostgreSQL) GetTableColumns(tableName string) ([]Column, error) {
+func (p *PostgreSQL) GetTableColumns(ctx context.Context, tableName string) ([]Column, error) {
+	schema, name := p.splitTableName(tableName)
+	columns, err := p.columns(ctx, []string{schema}, []string{name})
+	if err != nil {
+		return nil, err
+	}
+	
+	// information_schema.columns 不包含物化视图的列
+	if tableColumns := columns[p.tableKey(schema, name)]; len(tableColumns) > 0 {
+		return tableColumns, nil
+	}
+	enums, err := p.enumTypes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return p.relationColumns(ctx, schema, name, enums)
+}
+
+func (p *PostgreSQL) GetTableIndexes(ctx context.Context, tableName string) ([]Index, error) {
+	schema, name := p.splitTableName(tableName)
+	indexes, err := p.indexes(ctx, []string{schema}, []string{name})
+	if err != nil {
+		return nil, err
+	}
+	return indexes[p.tableKey(schema, name)], nil
+}
+
+func (p *PostgreSQL) GetTableForeignKeys(ctx context.Context, tableName string) ([]ForeignKey, error) {
+	schema, name := p.splitTableName(tableName)
+	foreignKeys, err := p.foreignKeys(ctx, []string{schema}, []string{name})
+	if err != nil {
+		return nil, err
+	}
+	return foreignKeys[p.tableKey(schema, name)], nil
+}
+
+// tableKey 返回批量查询结果的分组键，与 Table.QualifiedName 一致
+func (p *PostgreSQL) tableKey(schema, name string) string {
+	if len(p.Schemas) > 0 {
+		return schema + "." + name
+	}
+	return name
+}
+
+// columns 查询 schemas 与 names 中的表的列，按表分组；两个列表只用于缩小查询范围，
+// 其他 schema 中的同名表也会出现在结果中
+func (p *PostgreSQL) columns(ctx context.Context, schemas, names []string) (map[string][]Column, error) {
 	query := `
 		SELECT 
+			c.table_schema,
+			c.table_name,
 			c.column_name,
 			c.data_type,
+			c.udt_name,
 			c.is_nullable,
 			CASE WHEN pk.column_name IS NOT NULL THEN true ELSE false END as is_primary_key,
-			CASE WHEN c.column_default LIKE 'nextval%' THEN true ELSE false END as is_auto_incr,
+			CASE WHEN c.column_default LIKE 'nextval%' OR c.is_identity = 'YES' THEN true ELSE false END as is_auto_incr,
 			COALESCE(c.column_default, '') as column_default,
-			COALESCE(col_description(pgc.oid, c.ordinal_position), '') as column_comment
+			COALESCE(col_description(pgc.oid, c.ordinal_position), '') as column_comment,
+			COALESCE(c.character_maximum_length, 0) as length,
+			CASE WHEN c.numeric_precision_radix = 10 THEN c.numeric_precision ELSE 0 END as precision,
+			CASE WHEN c.numeric_precision_radix = 10 THEN c.numeric_scale ELSE 0 END as scale,
+			COALESCE(c.character_set_name, '') as charset,
+			COALESCE(c.collation_name, '') as collation,
+			COALESCE(c.is_generated, 'NEVER') as is_generated,
+			COALESCE(c.generation_expression, '') as generation_expression,
+			COALESCE(c.identity_generation, '') as identity_generation,
+			COALESCE(pg_get_serial_sequence(quote_ident(c.table_schema) || '.' || quote_ident(c.table_name), c.column_name), '') as sequence
 		FROM 
 			information_schema.columns c
 		LEFT JOIN 
 			(
-				SELECT ku.column_name
+				SELECT ku.table_schema, ku.table_name, ku.column_name
 				FROM information_schema.table_constraints tc
 				JOIN information_schema.key_column_usage ku
 					ON tc.constraint_name = ku.constraint_name
+					AND tc.table_schema = ku.table_schema
+					AND tc.table_name = ku.table_name
 				WHERE tc.constraint_type = 'PRIMARY KEY'
-					AND tc.table_name = $1
-			) pk ON c.column_name = pk.column_name
+					AND tc.table_schema = ANY($1)
+					AND tc.table_name = ANY($2)
+			) pk ON pk.table_schema = c.table_schema AND pk.table_name = c.table_name AND pk.column_name = c.column_name
+		LEFT JOIN 
+			pg_namespace n ON n.nspname = c.table_schema
 		LEFT JOIN 
-			pg_class pgc ON pgc.relname = c.table_name
+			pg_class pgc ON pgc.relname = c.table_name AND pgc.relnamespace = n.oid
 		WHERE 
-			c.table_name = $1
-			AND c.table_schema = 'public'
+			c.table_schema = ANY($1)
+			AND c.table_name = ANY($2)
 		ORDER BY 
-			c.ordinal_position
+			c.table_schema, c.table_name, c.ordinal_position
 	`
 	
-	rows, err := p.db.Query(query, tableName)
+	enums, err := p.enumTypes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	
+	rows, err := p.db.QueryContext(ctx, query, pq.Array(schemas), pq.Array(names))
 	if err != nil {
 		return nil, fmt.Errorf("查询列信息失败: %w", err)
 	}
 	defer rows.Close()
 	
-	var columns []Column
+	columns := make(map[string][]Column)
 	for rows.Next() {
 		var col Column
-		var nullable string
+		var schema, table, nullable, udtName, isGenerated string
+		var precision, scale sql.NullInt64
 		
 		if err := rows.Scan(
+			&schema,
+			&table,
 			&col.Name,
 			&col.Type,
+			&udtName,
 			&nullable,
 			&col.IsPrimaryKey,
 			&col.IsAutoIncr,
 			&col.DefaultValue,
 			&col.Comment,
+			&col.Length,
+			&precision,
+			&scale,
+			&col.Charset,
+			&col.Collation,
+			&isGenerated,
+			&col.GeneratedExpr,
+			&col.Identity,
+			&col.Sequence,
 		); err != nil {
 			return nil, fmt.Errorf("扫描列信息失败: %w", err)
 		}
 		
 		col.Nullable = nullable == "YES"
+		col.Precision = int(precision.Int64)
+		col.Scale = int(scale.Int64)
+		// PostgreSQL 只支持存储生成列
+		if isGenerated == "ALWAYS" {
+			col.Generated = "STORED"
+		}
+		
+		// 标识列和 serial 列的序列归属于列，其他表的序列只能从默认值中解析
+		if col.Sequence == "" {
+			col.Sequence = nextvalSequence(col.DefaultValue)
+		}
+		
+		// 数组和自定义类型的 data_type 为 ARRAY 和 USER-DEFINED，实际类型在 udt_name 中
+		switch col.Type {
+		case "ARRAY":
+			col.FullType = strings.TrimPrefix(udtName, "_") + "[]"
+		case "USER-DEFINED":
+			col.FullType = udtName
+			if values, ok := enums[udtName]; ok {
+				col.EnumName = udtName
+				col.EnumValues = values
+			}
+		}
 		
 		// 转换为 Go 类型
-		col.GoType = postgresTypeToGoType(col.Type, col.Nullable)
+		col.GoType = postgresColumnGoType(col)
 		
-		columns = append(columns, col)
+		key := p.tableKey(schema, table)
+		columns[key] = append(columns[key], col)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("读取列信息失败: %w", err)
 	}
 	
 	return columns, nil
 }
 
+// relationColumns 从 pg_attribute 读取物化视图等关系的列信息，类型名转换为 information_schema 中的形式
+func (p *PostgreSQL) relationColumns(ctx context.Context, schema, name string, enums map[string][]string) ([]Column, error) {
+	query := `
+		SELECT 
+			a.attname,
+			t.typname,
+			NOT a.attnotnull as nullable,
+			COALESCE(col_description(c.oid, a.attnum), '') as column_comment,
+			CASE WHEN a.atttypmod > 4 AND t.typname IN ('varchar', 'bpchar') THEN a.atttypmod - 4 ELSE 0 END as length
+		FROM 
+			pg_attribute a
+		JOIN 
+			pg_class c ON c.oid = a.attrelid
+		JOIN 
+			pg_namespace n ON n.oid = c.relnamespace
+		JOIN 
+			pg_type t ON t.oid = a.atttypid
+		WHERE 
+			n.nspname = $1
+			AND c.relname = $2
+			AND a.attnum > 0
+			AND NOT a.attisdropped
+		ORDER BY 
+			a.attnum
+	`
+	
+	rows, err := p.db.QueryContext(ctx, query, schema, name)
+	if err != nil {
+		return nil, fmt.Errorf("查询列信息失败: %w", err)
+	}
+	defer rows.Close()
+	
+	dataTypes := make(map[string]string, len(postgresUDTNames))
+	for dataType, udtName := range postgresUDTNames {
+		dataTypes[udtName] = dataType
+	}
+	
+	var columns []Column
+	for rows.Next() {
+		var col Column
+		var udtName string
+		if err := rows.Scan(&col.Name, &udtName, &col.Nullable, &col.Comment, &col.Length); err != nil {
+			return nil, fmt.Errorf("扫描列信息失败: %w", err)
+		}
+		
+		switch {
+		case strings.HasPrefix(udtName, "_"):
+			col.Type = "ARRAY"
+			col.FullType = udtName[1:] + "[]"
+		case enums[udtName] != nil:
+			col.Type = "USER-DEFINED"
+			col.FullType = udtName
+			col.EnumName = udtName
+			col.EnumValues = enums[udtName]
+		case dataTypes[udtName] != "":
+			col.Type = dataTypes[udtName]
+		default:
+			col.Type = udtName
+		}
+		
+		col.GoType = postgresColumnGoType(col)
+		columns = append(columns, col)
+	}
+	
+	return columns, rows.Err()
+}
+
+// enumTypes 读取要读取的 schema 中的枚举类型及其可选值，按定义顺序排列
+func (p *PostgreSQL) enumTypes(ctx context.Context) (map[string][]string, error) {
+	if p.enums != nil {
+		return p.enums, nil
+	}
+	
+	query := `
+		SELECT 
+			t.typname,
+			e.enumlabel
+		FROM 
+			pg_type t
+		JOIN 
+			pg_enum e ON e.enumtypid = t.oid
+		JOIN 
+			pg_namespace n ON n.oid = t.typnamespace
+		WHERE 
+			n.nspname = ANY($1)
+		ORDER BY 
+			t.typname, e.enumsortorder
+	`
+	
+	rows, err := p.db.QueryContext(ctx, query, pq.Array(p.schemas()))
+	if err != nil {
+		return nil, fmt.Errorf("查询枚举类型失败: %w", err)
+	}
+	defer rows.Close()
+	
+	enums := make(map[string][]string)
+	for rows.Next() {
+		var name, label string
+		if err := rows.Scan(&name, &label); err != nil {
+			return nil, fmt.Errorf("扫描枚举类型失败: %w", err)
+		}
+		enums[name] = append(enums[name], label)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("读取枚举类型失败: %w", err)
+	}
+	
+	p.enums = enums
+	return enums, nil
+}
+
+// indexes 查询 schemas 与 names 中的表的索引，按表分组
+func (p *PostgreSQL) indexes(ctx context.Context, schemas, names []string) (map[string][]Index, error) {
+	// 唯一约束在 PostgreSQL 中以唯一索引实现；跳过表达式索引和部分索引
+	query := `
+		SELECT 
+			n.nspname,
+			t.relname,
+			i.relname,
+			a.attname,
+			ix.indisunique,
+			ix.indisprimary
+		FROM 
+			pg_index ix
+		JOIN 
+			pg_class t ON t.oid = ix.indrelid
+		JOIN 
+			pg_class i ON i.oid = ix.indexrelid
+		JOIN 
+			pg_namespace n ON n.oid = t.relnamespace
+		JOIN LATERAL 
+			unnest(ix.indkey) WITH ORDINALITY AS k(attnum, ord) ON true
+		JOIN 
+			pg_attribute a ON a.attrelid = t.oid AND a.attnum = k.attnum
+		WHERE 
+			n.nspname = ANY($1)
+			AND t.relname = ANY($2)
+			AND ix.indexprs IS NULL
+			AND ix.indpred IS NULL
+		ORDER BY 
+			n.nspname, t.relname, i.relname, k.ord
+	`
+	
+	rows, err := p.db.QueryContext(ctx, query, pq.Array(schemas), pq.Array(names))
+	if err != nil {
+		return nil, fmt.Errorf("查询索引信息失败: %w", err)
+	}
+	defer rows.Close()
+	
+	indexes := make(map[string][]Index)
+	for rows.Next() {
+		var schema, table, indexName, columnName string
+		var unique, primary bool
+		
+		if err := rows.Scan(&schema, &table, &indexName, &columnName, &unique, &primary); err != nil {
+			return nil, fmt.Errorf("扫描索引信息失败: %w", err)
+		}
+		
+		key := p.tableKey(schema, table)
+		indexes[key] = appendIndexColumn(indexes[key], indexName, columnName, unique, primary)
+	}
+	
+	return indexes, rows.Err()
+}
+
+// foreignKeys 查询 schemas 与 names 中的表的外键，按表分组
+func (p *PostgreSQL) foreignKeys(ctx context.Context, schemas, names []string) (map[string][]ForeignKey, error) {
+	query := `
+		SELECT 
+			n.nspname,
+			t.relname,
+			con.conname,
+			a.attname,
+			rn.nspname,
+			rt.relname,
+			ra.attname
+		FROM 
+			pg_constraint con
+		JOIN 
+			pg_class t ON t.oid = con.conrelid
+		JOIN 
+			pg_namespace n ON n.oid = t.relnamespace
+		JOIN 
+			pg_class rt ON rt.oid = con.confrelid
+		JOIN 
+			pg_namespace rn ON rn.oid = rt.relnamespace
+		JOIN LATERAL 
+			unnest(con.conkey, con.confkey) WITH ORDINALITY AS k(attnum, refattnum, ord) ON true
+		JOIN 
+			pg_attribute a ON a.attrelid = con.conrelid AND a.attnum = k.attnum
+		JOIN 
+			pg_attribute ra ON ra.attrelid = con.confrelid AND ra.attnum = k.refattnum
+		WHERE 
+			con.contype = 'f'
+			AND n.nspname = ANY($1)
+			AND t.relname = ANY($2)
+		ORDER BY 
+			n.nspname, t.relname, con.conname, k.ord
+	`
+	
+	rows, err := p.db.QueryContext(ctx, query, pq.Array(schemas), pq.Array(names))
+	if err != nil {
+		return nil, fmt.Errorf("查询外键信息失败: %w", err)
+	}
+	defer rows.Close()
+	
+	foreignKeys := make(map[string][]ForeignKey)
+	for rows.Next() {
+		var schema, table, name, column, refSchema, refTable, refColumn string
+		
+		if err := rows.Scan(&schema, &table, &name, &column, &refSchema, &refTable, &refColumn); err != nil {
+			return nil, fmt.Errorf("扫描外键信息失败: %w", err)
+		}
+		
+		// 配置了 schemas 时引用表与 Table.QualifiedName 一致，带 schema
+		if len(p.Schemas) > 0 {
+			refTable = refSchema + "." + refTable
+		}
+		
+		key := p.tableKey(schema, table)
+		foreignKeys[key] = appendForeignKeyColumn(foreignKeys[key], name, column, refTable, refColumn)
+	}
+	
+	return foreignKeys, rows.Err()
+}
+
+// postgresColumnGoType 返回列的 Go 类型。枚举列按字符串处理，由生成器生成具名类型；
+// 数组和其他自定义类型按 udt_name 记录的完整类型转换
+func postgresColumnGoType(col Column) string {
+	switch {
+	case col.EnumName != "":
+		return postgresTypeToGoType("text", col.Nullable)
+	case col.Type == "ARRAY":
+		return postgresArrayGoType(strings.TrimSuffix(col.FullType, "[]"))
+	case col.Type == "USER-DEFINED" && col.FullType != "":
+		return postgresTypeToGoType(col.FullType, col.Nullable)
+	}
+	return postgresTypeToGoType(col.Type, col.Nullable)
+}
+
+// postgresArrayGoType 根据元素的 udt_name 返回 lib/pq 提供的数组类型，NULL 数组扫描为 nil 切片，无需指针
+func postgresArrayGoType(elemType string) string {
+	switch elemType {
+	case "int2", "int4":
+		return "pq.Int32Array"
+	case "int8":
+		return "pq.Int64Array"
+	case "float4":
+		return "pq.Float32Array"
+	case "float8", "numeric":
+		return "pq.Float64Array"
+	case "bool":
+		return "pq.BoolArray"
+	case "bytea":
+		return "pq.ByteaArray"
+	default:
+		// 其他元素类型（文本、uuid、日期、枚举等）按文本形式读取
+		return "pq.StringArray"
+	}
+}
+
+// postgresUDTNames information_schema 中的 data_type 到 udt_name 的映射，未列出的类型两者相同
+var postgresUDTNames = map[string]string{
+	"smallint":                    "int2",
+	"integer":                     "int4",
+	"bigint":                      "int8",
+	"real":                        "float4",
+	"double precision":            "float8",
+	"boolean":                     "bool",
+	"character varying":           "varchar",
+	"character":                   "bpchar",
+	"timestamp without time zone": "timestamp",
+	"timestamp with time zone":    "timestamptz",
+	"time without time zone":      "time",
+	"time with time zone":         "timetz",
+}
+
 // postgresTypeToGoType 将 PostgreSQL 类型转换为 Go 类型
 func postgresTypeToGoType(pgType string, nullable bool) string {
 	baseType := strings.ToLower(pgType)
@@ -155,7 +630,7 @@ func postgresTypeToGoType(pgType string, nullable bool) string {
 		goType = "float64"
 	case "character varying", "varchar", "character", "char", "text":
 		goType = "string"
-	case "timestamp", "timestamp without time zone", "timestamp with time zone", "date", "time":
+	case "timestamp", "timestamp without time zone", "timestamp with time zone", "date", "time", "time without time zone", "time with time zone":
 		goType = "time.Time"
 	case "boolean", "bool":
 		goType = "bool"
@@ -163,8 +638,19 @@ func postgresTypeToGoType(pgType string, nullable bool) string {
 		goType = "json.RawMessage"
 	case "bytea":
 		goType = "[]byte"
-	case "uuid":
+	case "uuid", "money", "xml", "citext":
+		// money 的文本格式受 lc_monetary 影响，按字符串读取
 		goType = "string"
+	case "inet":
+		goType = "Inet"
+	case "cidr":
+		goType = "Cidr"
+	case "macaddr", "macaddr8":
+		goType = "MacAddr"
+	case "interval":
+		goType = "Interval"
+	case "tsrange", "tstzrange", "daterange":
+		goType = "TimeRange"
 	default:
 		goType = "interface{}"
 	}
@@ -175,4 +661,17 @@ func postgresTypeToGoType(pgType string, nullable bool) string {
 	}
 	
 	return goType
+}
+
+// nextvalSequence 从 nextval('users_id_seq'::regclass) 形式的默认值中解析序列名
+func nextvalSequence(defaultValue string) string {
+	if !strings.HasPrefix(defaultValue, "nextval(") {
+		return ""
+	}
+	start := strings.Index(defaultValue, "'")
+	end := strings.LastIndex(defaultValue, "'")
+	if start < 0 || end <= start {
+		return ""
+	}
+	return defaultValue[start+1 : end]
 }
\ No newline at end of file
@@ -0,0 +1,156 @@
+package database
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/lib/pq"
+)
+
+// Factory 表结构来源驱动，通过 Register 注册后即可在 database.driver 中使用
+type Factory interface {
+	// Open 创建数据源，schemas 为要读取的 schema 或数据库，驱动不支持时可以忽略
+	Open(dsn string, schemas []string) (Database, error)
+	// ValidateDSN 校验连接字符串，调用前已确认连接字符串非空
+	ValidateDSN(dsn string) error
+	// Dialect 返回生成 SQL、迁移脚本和匹配类型映射使用的方言：mysql、postgres、sqlite，无法对应时返回空字符串
+	Dialect() string
+	// GoType 将数据库类型转换为 Go 类型，可空列返回指针等可以表示 NULL 的类型；
+	// 数据源返回的列没有设置 GoType 时由生成器调用，dbType 为完整类型或数据类型
+	GoType(dbType string, nullable bool) string
+}
+
+// FileSource 从本地文件读取表结构、不需要连接字符串的驱动，Factory 可以选择实现。
+// 配置此类驱动时使用 database.files 和 database.dialect 代替连接配置
+type FileSource interface {
+	// ValidateFiles 校验文件列表和文件的 SQL 方言，dialect 为空时使用默认方言
+	ValidateFiles(files []string, dialect string) error
+	// OpenFiles 创建读取 files 的数据源
+	OpenFiles(files []string, dialect string) (Database, error)
+	// FileDialect 返回文件实际使用的 SQL 方言，dialect 为空时返回默认方言
+	FileDialect(dialect string) string
+}
+
+var (
+	driversMu sync.RWMutex
+	drivers   = make(map[string]Factory)
+)
+
+// Register 注册驱动，通常在驱动包的 init 中调用；名称为空、重复或 factory 为 nil 时 panic
+func Register(name string, factory Factory) {
+	driversMu.Lock()
+	defer driversMu.Unlock()
+	
+	if name == "" || factory == nil {
+		panic("database: 注册驱动时名称和 factory 不能为空")
+	}
+	if _, ok := drivers[name]; ok {
+		panic(fmt.Sprintf("database: 驱动 %s 已注册", name))
+	}
+	drivers[name] = factory
+}
+
+// Lookup 返回已注册的驱动
+func Lookup(name string) (Factory, bool) {
+	driversMu.RLock()
+	defer driversMu.RUnlock()
+	
+	factory, ok := drivers[name]
+	return factory, ok
+}
+
+// Drivers 返回已注册的驱动名，按名称排序
+func Drivers() []string {
+	driversMu.RLock()
+	defer driversMu.RUnlock()
+	
+	names := make([]string, 0, len(drivers))
+	for name := range drivers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// builtinDriver 内置驱动的 Factory 实现
+type builtinDriver struct {
+	dialect     string
+	open        func(dsn string, schemas []string) Database
+	validateDSN func(dsn string) error
+	buildDSN    func(params ConnectionParams) (string, error)
+	goType      func(dbType string, nullable bool) string
+}
+
+func (d builtinDriver) Open(dsn string, schemas []string) (Database, error) {
+	return d.open(dsn, schemas), nil
+}
+
+func (d builtinDriver) ValidateDSN(dsn string) error {
+	if d.validateDSN == nil {
+		return nil
+	}
+	return d.validateDSN(dsn)
+}
+
+func (d builtinDriver) BuildDSN(params ConnectionParams) (string, error) {
+	return d.buildDSN(params)
+}
+
+func (d builtinDriver) Dialect() string {
+	return d.dialect
+}
+
+func (d builtinDriver) GoType(dbType string, nullable bool) string {
+	return d.goType(dbType, nullable)
+}
+
+func init() {
+	Register("mysql", builtinDriver{
+		dialect: "mysql",
+		open: func(dsn string, schemas []string) Database {
+			return &MySQL{DSN: dsn, Schemas: schemas}
+		},
+		validateDSN: func(dsn string) error {
+			_, err := mysql.ParseDSN(dsn)
+			return err
+		},
+		buildDSN: buildMySQLDSN,
+		goType:   mysqlTypeToGoType,
+	})
+	
+	Register("postgres", builtinDriver{
+		dialect: "postgres",
+		open: func(dsn string, schemas []string) Database {
+			return &PostgreSQL{DSN: dsn, Schemas: schemas}
+		},
+		validateDSN: func(dsn string) error {
+			// key=value 形式的连接字符串在连接时才会解析，这里只校验 URL 形式
+			if strings.HasPrefix(dsn, "postgres://") || strings.HasPrefix(dsn, "postgresql://") {
+				_, err := pq.ParseURL(dsn)
+				return err
+			}
+			return nil
+		},
+		buildDSN: buildPostgresDSN,
+		goType: func(dbType string, nullable bool) string {
+			if strings.HasSuffix(dbType, "[]") {
+				return postgresArrayGoType(strings.TrimSuffix(dbType, "[]"))
+			}
+			return postgresTypeToGoType(dbType, nullable)
+		},
+	})
+	
+	Register("sqlite", builtinDriver{
+		dialect: "sqlite",
+		open: func(dsn string, schemas []string) Database {
+			return &SQLite{DSN: dsn}
+		},
+		buildDSN: buildSQLiteDSN,
+		goType:   sqliteTypeToGoType,
+	})
+	
+	Register("ddl", ddlDriver{})
+}
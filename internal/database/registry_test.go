@@ -0,0 +1,96 @@
+package database
+
+import (
+	"fmt"
+	"testing"
+)
+
+// stubDriver 测试用驱动，从内存中的表结构读取
+type stubDriver struct{}
+
+func (stubDriver) Open(dsn string, schemas []string) (Database, error) {
+	return &Snapshot{Path: dsn}, nil
+}
+
+func (stubDriver) ValidateDSN(dsn string) error {
+	if dsn != "stub://catalog" {
+		return fmt.Errorf("无效的连接字符串: %s", dsn)
+	}
+	return nil
+}
+
+func (stubDriver) Dialect() string {
+	return "mysql"
+}
+
+func (stubDriver) GoType(dbType string, nullable bool) string {
+	return mysqlTypeToGoType(dbType, nullable)
+}
+
+func TestRegister(t *testing.T) {
+	Register("stub-registry", stubDriver{})
+	
+	factory, ok := Lookup("stub-registry")
+	if !ok || factory.Dialect() != "mysql" {
+		t.Fatalf("期望查找到已注册的驱动")
+	}
+	db, err := NewDatabase("stub-registry", "schema.yaml", nil)
+	if err != nil {
+		t.Fatalf("创建数据源失败: %v", err)
+	}
+	if snapshot, ok := db.(*Snapshot); !ok || snapshot.Path != "schema.yaml" {
+		t.Errorf("期望使用注册的驱动创建数据源，实际为 %#v", db)
+	}
+	
+	found := false
+	for _, name := range Drivers() {
+		found = found || name == "stub-registry"
+	}
+	if !found {
+		t.Errorf("期望驱动列表包含注册的驱动，实际为 %v", Drivers())
+	}
+	
+	defer func() {
+		if recover() == nil {
+			t.Error("期望重复注册时 panic")
+		}
+	}()
+	Register("stub-registry", stubDriver{})
+}
+
+func TestBuiltinDrivers(t *testing.T) {
+	mysql, _ := Lookup("mysql")
+	if err := mysql.ValidateDSN("user:password@tcp(localhost:3306)/app"); err != nil {
+		t.Errorf("期望 MySQL 连接字符串有效，实际为 %v", err)
+	}
+	if err := mysql.ValidateDSN("localhost:3306"); err == nil {
+		t.Error("期望缺少 / 的 MySQL 连接字符串无效")
+	}
+	if goType := mysql.GoType("int(10) unsigned", true); goType != "*uint32" {
+		t.Errorf("期望 MySQL 类型转换为 *uint32，实际为 %s", goType)
+	}
+	
+	postgres, _ := Lookup("postgres")
+	if err := postgres.ValidateDSN("postgres://user@localhost:5432/app?sslmode=disable"); err != nil {
+		t.Errorf("期望 PostgreSQL 连接字符串有效，实际为 %v", err)
+	}
+	if goType := postgres.GoType("int4[]", true); goType != "pq.Int32Array" {
+		t.Errorf("期望 PostgreSQL 数组类型转换为 pq.Int32Array，实际为 %s", goType)
+	}
+	
+	ddl, _ := Lookup("ddl")
+	source, ok := ddl.(FileSource)
+	if !ok {
+		t.Fatal("期望 ddl 驱动实现 FileSource")
+	}
+	if _, err := source.OpenFiles([]string{"schema.sql"}, "postgres"); err != nil {
+		t.Errorf("期望创建 DDL 数据源，实际为 %v", err)
+	}
+	if _, err := ddl.Open("", nil); err == nil {
+		t.Error("期望 ddl 驱动不支持通过连接字符串打开")
+	}
+	
+	if _, err := NewDatabase("oracle", "", nil); err == nil {
+		t.Error("期望未注册的驱动返回错误")
+	}
+}
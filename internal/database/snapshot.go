@@ -0,0 +1,95 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	
+	"gopkg.in/yaml.v3"
+)
+
+// Snapshot 从 inspect 命令导出的表结构快照读取表结构，无需连接数据库
+type Snapshot struct {
+	Path   string // 快照文件路径，.yaml/.yml 按 YAML 解析，其他按 JSON 解析
+	tables []Table
+}
+
+// NewSnapshot 创建表结构快照数据源
+func NewSnapshot(path string) *Snapshot {
+	return &Snapshot{Path: path}
+}
+
+func (s *Snapshot) Connect(ctx context.Context) error {
+	tables, err := LoadSnapshot(s.Path)
+	if err != nil {
+		return err
+	}
+	s.tables = tables
+	return nil
+}
+
+func (s *Snapshot) Close() error {
+	return nil
+}
+
+func (s *Snapshot) GetTables(ctx context.Context, filter TableFilter) ([]Table, error) {
+	return filter.Apply(s.tables), nil
+}
+
+func (s *Snapshot) GetTableColumns(ctx context.Context, tableName string) ([]Column, error) {
+	table, err := s.findTable(tableName)
+	if err != nil {
+		return nil, err
+	}
+	return table.Columns, nil
+}
+
+func (s *Snapshot) GetTableIndexes(ctx context.Context, tableName string) ([]Index, error) {
+	table, err := s.findTable(tableName)
+	if err != nil {
+		return nil, err
+	}
+	return table.Indexes, nil
+}
+
+func (s *Snapshot) GetTableForeignKeys(ctx context.Context, tableName string) ([]ForeignKey, error) {
+	table, err := s.findTable(tableName)
+	if err != nil {
+		return nil, err
+	}
+	return table.ForeignKeys, nil
+}
+
+// findTable 按表名查找快照中的表
+func (s *Snapshot) findTable(tableName string) (*Table, error) {
+	for i := range s.tables {
+		if s.tables[i].Name == tableName || s.tables[i].QualifiedName() == tableName {
+			return &s.tables[i], nil
+		}
+	}
+	return nil, fmt.Errorf("表 %s 不存在", tableName)
+}
+
+// LoadSnapshot 读取表结构快照文件
+func LoadSnapshot(path string) ([]Table, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("读取表结构快照失败: %w", err)
+	}
+	
+	var tables []Table
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &tables)
+	default:
+		err = json.Unmarshal(data, &tables)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("解析表结构快照 %s 失败: %w", path, err)
+	}
+	
+	return tables, nil
+}
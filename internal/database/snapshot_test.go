@@ -0,0 +1,60 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestLoadSnapshot(t *testing.T) {
+	tables := []Table{
+		{
+			Name:    "users",
+			Comment: "用户表",
+			Columns: []Column{
+				{Name: "id", Type: "bigint", GoType: "int64", IsPrimaryKey: true, IsAutoIncr: true},
+				{Name: "email", Type: "varchar", GoType: "*string", Nullable: true},
+			},
+			Indexes: []Index{
+				{Name: "PRIMARY", Columns: []string{"id"}, IsUnique: true, IsPrimary: true},
+			},
+		},
+	}
+	
+	tmpDir := t.TempDir()
+	jsonData, err := json.Marshal(tables)
+	if err != nil {
+		t.Fatalf("序列化 JSON 失败: %v", err)
+	}
+	yamlData, err := yaml.Marshal(tables)
+	if err != nil {
+		t.Fatalf("序列化 YAML 失败: %v", err)
+	}
+	
+	files := map[string][]byte{
+		filepath.Join(tmpDir, "schema.json"): jsonData,
+		filepath.Join(tmpDir, "schema.yaml"): yamlData,
+	}
+	for path, data := range files {
+		if err := os.WriteFile(path, data, 0644); err != nil {
+			t.Fatalf("创建快照文件失败: %v", err)
+		}
+		
+		db := NewSnapshot(path)
+		if err := db.Connect(context.Background()); err != nil {
+			t.Fatalf("读取快照 %s 失败: %v", path, err)
+		}
+		loaded, err := db.GetTables(context.Background(), TableFilter{})
+		if err != nil {
+			t.Fatalf("获取表信息失败: %v", err)
+		}
+		if !reflect.DeepEqual(loaded, tables) {
+			t.Errorf("快照 %s 内容不一致:\n期望 %+v\n实际 %+v", path, tables, loaded)
+		}
+	}
+}
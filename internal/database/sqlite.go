@@ -1,6 +1,7 @@
 package database
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"strings"
@@ -12,14 +13,16 @@ type SQLite struct {
 	db  *sql.DB
 }
 
-func (s *SQLite) Connect() error {
+func (s *SQLite) Connect(ctx context.Context) error {
 	db, err := sql.Open("sqlite3", s.DSN)
 	if err != nil {
-		return fmt.Errorf("连接 SQLite 失败: %w", err)
+		return fmt.Errorf("连接 SQLite 失败: %w", RedactError(err, s.DSN))
 	}
 	
-	if err := db.Ping(); err != nil {
-		return fmt.Errorf("ping SQLite 失败: %w", err)
+	// 连接失败时关闭连接池，避免重试时泄漏
+	if err := db.PingContext(ctx); err != nil {
+		db.Close()
+		return fmt.Errorf("ping SQLite 失败: %w", RedactError(err, s.DSN))
 	}
 	
 	s.db = db
@@ -33,130 +36,406 @@ func (s *SQLite) Close() error {
 	return nil
 }
 
-func (s *SQLite) GetTables() ([]Table, error) {
+// GetTables 先读取表清单并按 filter 筛选，再通过 pragma 表值函数为选中的表批量查询列、索引和外键
+func (s *SQLite) GetTables(ctx context.Context, filter TableFilter) ([]Table, error) {
 	query := `
 		SELECT 
 			name,
-			'' as table_comment
+			type,
+			COALESCE(sql, '') as create_sql
 		FROM 
 			sqlite_master 
 		WHERE 
-			type = 'table'
+			type IN ('table', 'view')
 			AND name NOT LIKE 'sqlite_%'
 		ORDER BY 
 			name
 	`
 	
-	rows, err := s.db.Query(query)
+	rows, err := s.db.QueryContext(ctx, query)
 	if err != nil {
 		return nil, fmt.Errorf("查询表信息失败: %w", err)
 	}
 	defer rows.Close()
 	
 	var tables []Table
+	definitions := make(map[string]*Table)
 	for rows.Next() {
 		var table Table
-		if err := rows.Scan(&table.Name, &table.Comment); err != nil {
+		var tableType, createSQL string
+		if err := rows.Scan(&table.Name, &tableType, &createSQL); err != nil {
 			return nil, fmt.Errorf("扫描表信息失败: %w", err)
 		}
-		
-		// 获取列信息
-		columns, err := s.GetTableColumns(table.Name)
-		if err != nil {
-			return nil, fmt.Errorf("获取表 %s 的列信息失败: %w", table.Name, err)
+		if !filter.Match(table) {
+			continue
 		}
-		table.Columns = columns
 		
+		if tableType == "view" {
+			table.Kind = KindView
+		} else if definition := parseTableDefinition(createSQL); definition != nil {
+			table.Strict = definition.Strict
+			table.WithoutRowid = definition.WithoutRowid
+			definitions[table.Name] = definition
+		}
 		tables = append(tables, table)
 	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("读取表信息失败: %w", err)
+	}
+	rows.Close()
+	
+	if len(tables) == 0 {
+		return nil, nil
+	}
+	
+	names := make([]string, len(tables))
+	for i, table := range tables {
+		names[i] = table.Name
+	}
+	
+	columns, err := s.columns(ctx, names, definitions)
+	if err != nil {
+		return nil, fmt.Errorf("获取列信息失败: %w", err)
+	}
+	indexes, err := s.indexes(ctx, names)
+	if err != nil {
+		return nil, fmt.Errorf("获取索引信息失败: %w", err)
+	}
+	foreignKeys, err := s.foreignKeys(ctx, names)
+	if err != nil {
+		return nil, fmt.Errorf("获取外键信息失败: %w", err)
+	}
+	
+	for i := range tables {
+		tables[i].Columns = columns[tables[i].Name]
+		tables[i].Indexes = indexes[tables[i].Name]
+		tables[i].ForeignKeys = foreignKeys[tables[i].Name]
+	}
 	
 	return tables, nil
 }
 
-func (s *SQLite) GetTableColumns(tableName string) ([]Column, error) {
-	// SQLite 使用 PRAGMA table_info 获取列信息
-	query := fmt.Sprintf("PRAGMA table_info(%s)", tableName)
+func (s *SQLite) GetTableColumns(ctx context.Context, tableName string) ([]Column, error) {
+	definitions := make(map[string]*Table)
+	if definition := s.tableDefinition(ctx, tableName); definition != nil {
+		definitions[tableName] = definition
+	}
 	
-	rows, err := s.db.Query(query)
+	columns, err := s.columns(ctx, []string{tableName}, definitions)
+	if err != nil {
+		return nil, err
+	}
+	// 表名不区分大小写，结果按 sqlite_master 中的表名分组，最多只有一组
+	for _, tableColumns := range columns {
+		return tableColumns, nil
+	}
+	return nil, nil
+}
+
+func (s *SQLite) GetTableIndexes(ctx context.Context, tableName string) ([]Index, error) {
+	indexes, err := s.indexes(ctx, []string{tableName})
+	if err != nil {
+		return nil, err
+	}
+	for _, tableIndexes := range indexes {
+		return tableIndexes, nil
+	}
+	return nil, nil
+}
+
+func (s *SQLite) GetTableForeignKeys(ctx context.Context, tableName string) ([]ForeignKey, error) {
+	foreignKeys, err := s.foreignKeys(ctx, []string{tableName})
+	if err != nil {
+		return nil, err
+	}
+	for _, tableForeignKeys := range foreignKeys {
+		return tableForeignKeys, nil
+	}
+	return nil, nil
+}
+
+// tableNameArgs 将表名转换为查询参数
+func tableNameArgs(names []string) []interface{} {
+	args := make([]interface{}, len(names))
+	for i, name := range names {
+		args[i] = name
+	}
+	return args
+}
+
+// columns 查询 names 中各表的列，按 sqlite_master 中的表名分组，definitions 为按表名索引的建表语句解析结果
+func (s *SQLite) columns(ctx context.Context, names []string, definitions map[string]*Table) (map[string][]Column, error) {
+	// SQLite 使用 table_xinfo 获取列信息，比 table_info 多出标识生成列的 hidden 列；
+	// 表名作为参数传入 pragma 函数，无需处理引号
+	query := `
+		SELECT 
+			m.name,
+			p.cid,
+			p.name,
+			p.type,
+			p."notnull",
+			p.dflt_value,
+			p.pk,
+			p.hidden
+		FROM 
+			sqlite_master m
+		JOIN 
+			pragma_table_xinfo(m.name) p
+		WHERE 
+			m.type IN ('table', 'view')
+			AND m.name COLLATE NOCASE IN (` + placeholders(len(names)) + `)
+		ORDER BY 
+			m.name, p.cid
+	`
+	
+	rows, err := s.db.QueryContext(ctx, query, tableNameArgs(names)...)
 	if err != nil {
 		return nil, fmt.Errorf("查询列信息失败: %w", err)
 	}
 	defer rows.Close()
 	
-	var columns []Column
+	columns := make(map[string][]Column)
 	for rows.Next() {
 		var col Column
+		var table string
 		var cid int
 		var notNull int
+		var pk int
+		var hidden int
 		var defaultValue sql.NullString
 		
 		if err := rows.Scan(
+			&table,
 			&cid,
 			&col.Name,
 			&col.Type,
 			&notNull,
 			&defaultValue,
-			&col.IsPrimaryKey,
+			&pk,
+			&hidden,
 		); err != nil {
 			return nil, fmt.Errorf("扫描列信息失败: %w", err)
 		}
 		
+		// hidden 为 1 表示虚拟表的隐藏列，2 和 3 分别表示虚拟生成列和存储生成列
+		switch hidden {
+		case 1:
+			continue
+		case 2:
+			col.Generated = "VIRTUAL"
+		case 3:
+			col.Generated = "STORED"
+		}
+		
+		// 排序规则、生成列表达式、自增和注释只能从建表语句中获取
+		col.Length, col.Precision, col.Scale = parseTypeSize(col.Type)
+		if definition := definitionColumn(definitions[table], col.Name); definition != nil {
+			col.Collation = definition.Collation
+			col.GeneratedExpr = definition.GeneratedExpr
+			col.IsAutoIncr = definition.IsAutoIncr
+			col.Comment = definition.Comment
+		}
+		
 		col.Nullable = notNull == 0
+		// pk 为列在主键中的序号（从 1 开始），复合主键时会大于 1
+		col.IsPrimaryKey = pk > 0
 		if defaultValue.Valid {
 			col.DefaultValue = defaultValue.String
 		}
 		
-		// SQLite 自增检查需要额外查询
-		col.IsAutoIncr = s.isAutoIncrement(tableName, col.Name)
-		
 		// 转换为 Go 类型
 		col.GoType = sqliteTypeToGoType(col.Type, col.Nullable)
 		
-		columns = append(columns, col)
+		columns[table] = append(columns[table], col)
+	}
+	
+	return columns, rows.Err()
+}
+
+// definitionColumn 在建表语句的解析结果中按列名查找列，列名不区分大小写
+func definitionColumn(definition *Table, name string) *Column {
+	if definition == nil {
+		return nil
+	}
+	for i := range definition.Columns {
+		if strings.EqualFold(definition.Columns[i].Name, name) {
+			return &definition.Columns[i]
+		}
+	}
+	return nil
+}
+
+// indexes 查询 names 中各表的索引，按表名分组
+func (s *SQLite) indexes(ctx context.Context, names []string) (map[string][]Index, error) {
+	// origin: c = CREATE INDEX, u = UNIQUE 约束, pk = PRIMARY KEY 约束
+	query := `
+		SELECT 
+			m.name,
+			il.name,
+			il."unique",
+			il.origin,
+			ii.name
+		FROM 
+			sqlite_master m
+		JOIN 
+			pragma_index_list(m.name) il
+		JOIN 
+			pragma_index_info(il.name) ii
+		WHERE 
+			m.type = 'table'
+			AND m.name COLLATE NOCASE IN (` + placeholders(len(names)) + `)
+			AND il.partial = 0
+		ORDER BY 
+			m.name, il.name, ii.seqno
+	`
+	
+	rows, err := s.db.QueryContext(ctx, query, tableNameArgs(names)...)
+	if err != nil {
+		return nil, fmt.Errorf("查询索引信息失败: %w", err)
+	}
+	defer rows.Close()
+	
+	indexes := make(map[string][]Index)
+	expressions := make(map[string]bool)
+	for rows.Next() {
+		var table, indexName, origin string
+		var unique bool
+		var columnName sql.NullString
+		
+		if err := rows.Scan(&table, &indexName, &unique, &origin, &columnName); err != nil {
+			return nil, fmt.Errorf("扫描索引信息失败: %w", err)
+		}
+		
+		// 表达式索引的列名为 NULL，整个索引跳过
+		if !columnName.Valid {
+			expressions[indexName] = true
+			continue
+		}
+		indexes[table] = appendIndexColumn(indexes[table], indexName, columnName.String, unique, origin == "pk")
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("扫描索引信息失败: %w", err)
 	}
 	
-	return columns, nil
+	for table, tableIndexes := range indexes {
+		var result []Index
+		for _, index := range tableIndexes {
+			if !expressions[index.Name] {
+				result = append(result, index)
+			}
+		}
+		indexes[table] = result
+	}
+	
+	return indexes, nil
 }
 
-// isAutoIncrement 检查列是否为自增
-func (s *SQLite) isAutoIncrement(tableName, columnName string) bool {
+// foreignKeys 查询 names 中各表的外键，按表名分组
+func (s *SQLite) foreignKeys(ctx context.Context, names []string) (map[string][]ForeignKey, error) {
+	// SQLite 的外键没有名称，使用 id 生成稳定的名称；省略引用列时 "to" 为 NULL，表示引用主键
 	query := `
-		SELECT sql 
-		FROM sqlite_master 
-		WHERE type = 'table' AND name = ?
+		SELECT 
+			m.name,
+			fk.id,
+			fk."table",
+			fk."from",
+			fk."to"
+		FROM 
+			sqlite_master m
+		JOIN 
+			pragma_foreign_key_list(m.name) fk
+		WHERE 
+			m.type = 'table'
+			AND m.name COLLATE NOCASE IN (` + placeholders(len(names)) + `)
+		ORDER BY 
+			m.name, fk.id, fk.seq
 	`
 	
-	var createSQL string
-	err := s.db.QueryRow(query, tableName).Scan(&createSQL)
+	rows, err := s.db.QueryContext(ctx, query, tableNameArgs(names)...)
 	if err != nil {
-		return false
+		return nil, fmt.Errorf("查询外键信息失败: %w", err)
 	}
 	
-	// 检查 CREATE TABLE 语句中该列是否包含 AUTOINCREMENT
-	upperSQL := strings.ToUpper(createSQL)
-	upperColumn := strings.ToUpper(columnName)
+	foreignKeys := make(map[string][]ForeignKey)
+	for rows.Next() {
+		var id int
+		var table, refTable, column string
+		var refColumn sql.NullString
+		
+		if err := rows.Scan(&table, &id, &refTable, &column, &refColumn); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("扫描外键信息失败: %w", err)
+		}
+		
+		name := fmt.Sprintf("fk_%s_%d", table, id)
+		foreignKeys[table] = appendForeignKeyColumn(foreignKeys[table], name, column, refTable, refColumn.String)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("扫描外键信息失败: %w", err)
+	}
+	
+	// 补全隐式引用主键的列
+	for _, tableForeignKeys := range foreignKeys {
+		for i, fk := range tableForeignKeys {
+			if fk.RefColumns[0] != "" {
+				continue
+			}
+			refColumns, err := s.getPrimaryKeyColumns(ctx, fk.RefTable)
+			if err != nil {
+				return nil, err
+			}
+			if len(refColumns) == len(fk.Columns) {
+				tableForeignKeys[i].RefColumns = refColumns
+			}
+		}
+	}
 	
-	// 查找列定义的位置
-	columnIndex := strings.Index(upperSQL, upperColumn)
-	if columnIndex == -1 {
-		return false
+	return foreignKeys, nil
+}
+
+// getPrimaryKeyColumns 获取表的主键列，按主键中的顺序排列
+func (s *SQLite) getPrimaryKeyColumns(ctx context.Context, tableName string) ([]string, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT name FROM pragma_table_info(?) WHERE pk > 0 ORDER BY pk`, tableName)
+	if err != nil {
+		return nil, fmt.Errorf("查询表 %s 的主键失败: %w", tableName, err)
 	}
+	defer rows.Close()
 	
-	// 查找该列定义的结束位置（下一个逗号或右括号）
-	searchStart := columnIndex + len(upperColumn)
-	nextComma := strings.Index(upperSQL[searchStart:], ",")
-	nextParen := strings.Index(upperSQL[searchStart:], ")")
+	var columns []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, fmt.Errorf("扫描表 %s 的主键失败: %w", tableName, err)
+		}
+		columns = append(columns, name)
+	}
 	
-	endIndex := len(upperSQL)
-	if nextComma != -1 && (nextParen == -1 || nextComma < nextParen) {
-		endIndex = searchStart + nextComma
-	} else if nextParen != -1 {
-		endIndex = searchStart + nextParen
+	return columns, rows.Err()
+}
+
+// tableDefinition 读取并解析 sqlite_master 中的建表语句，视图或解析失败时返回 nil
+func (s *SQLite) tableDefinition(ctx context.Context, tableName string) *Table {
+	var createSQL string
+	err := s.db.QueryRowContext(ctx, `SELECT sql FROM sqlite_master WHERE type = 'table' AND name = ?`, tableName).Scan(&createSQL)
+	if err != nil {
+		return nil
+	}
+	return parseTableDefinition(createSQL)
+}
+
+// parseTableDefinition 解析建表语句，用于读取自增、注释、排序规则等 PRAGMA 不提供的信息，解析失败时返回 nil
+func parseTableDefinition(createSQL string) *Table {
+	parser := newDDLParser("sqlite")
+	if err := parser.Parse(createSQL); err != nil {
+		return nil
 	}
 	
-	// 检查该列定义中是否包含 AUTOINCREMENT
-	columnDef := upperSQL[columnIndex:endIndex]
-	return strings.Contains(columnDef, "AUTOINCREMENT")
+	tables := parser.Tables()
+	if len(tables) != 1 {
+		return nil
+	}
+	return &tables[0]
 }
 
 // sqliteTypeToGoType 将 SQLite 类型转换为 Go 类型
@@ -0,0 +1,123 @@
+package database
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+)
+
+func TestSQLiteIntrospection(t *testing.T) {
+	db := &SQLite{DSN: filepath.Join(t.TempDir(), "test.db")}
+	if err := db.Connect(context.Background()); err != nil {
+		t.Fatalf("连接 SQLite 失败: %v", err)
+	}
+	defer db.Close()
+	
+	ddl := `
+		CREATE TABLE "user accounts" (
+			user_id INTEGER NOT NULL, -- 外部用户 ID
+			id INTEGER PRIMARY KEY,   -- 主键
+			name TEXT                 -- 用户名
+		);
+		CREATE TABLE counters (
+			name TEXT PRIMARY KEY,
+			value INTEGER NOT NULL DEFAULT 0
+		) WITHOUT ROWID, STRICT;
+		CREATE TABLE events (id INTEGER PRIMARY KEY AUTOINCREMENT, payload BLOB);
+		CREATE TABLE tags (id INT PRIMARY KEY, label TEXT);
+	`
+	if _, err := db.db.Exec(ddl); err != nil {
+		t.Fatalf("创建表失败: %v", err)
+	}
+	
+	tables, err := db.GetTables(context.Background(), TableFilter{})
+	if err != nil {
+		t.Fatalf("获取表信息失败: %v", err)
+	}
+	byName := make(map[string]Table)
+	for _, table := range tables {
+		byName[table.Name] = table
+	}
+	
+	accounts := byName["user accounts"]
+	if len(accounts.Columns) != 3 {
+		t.Fatalf("期望读取带空格的表名的列，实际为 %+v", accounts.Columns)
+	}
+	expected := []struct {
+		autoIncr bool
+		comment  string
+	}{
+		{false, "外部用户 ID"},
+		{true, "主键"},
+		{false, "用户名"},
+	}
+	for i, col := range accounts.Columns {
+		if col.IsAutoIncr != expected[i].autoIncr || col.Comment != expected[i].comment {
+			t.Errorf("列 %s: 期望自增 %v、注释 %q，实际为 %v、%q", col.Name, expected[i].autoIncr, expected[i].comment, col.IsAutoIncr, col.Comment)
+		}
+	}
+	
+	counters := byName["counters"]
+	if !counters.Strict || !counters.WithoutRowid {
+		t.Errorf("期望 counters 为 STRICT 和 WITHOUT ROWID 表: %+v", counters)
+	}
+	if byName["events"].Columns[0].IsAutoIncr != true {
+		t.Error("期望 AUTOINCREMENT 列为自增")
+	}
+	if byName["tags"].Columns[0].IsAutoIncr {
+		t.Error("期望 INT PRIMARY KEY 不是 rowid 别名")
+	}
+}
+
+func TestSQLiteTableFilter(t *testing.T) {
+	db := &SQLite{DSN: filepath.Join(t.TempDir(), "test.db")}
+	if err := db.Connect(context.Background()); err != nil {
+		t.Fatalf("连接 SQLite 失败: %v", err)
+	}
+	defer db.Close()
+	
+	ddl := `
+		CREATE TABLE users (id INTEGER PRIMARY KEY, email TEXT NOT NULL UNIQUE, name TEXT);
+		CREATE INDEX idx_users_name ON users (name);
+		CREATE INDEX idx_users_lower_email ON users (lower(email));
+		CREATE TABLE orders (id INTEGER PRIMARY KEY, user_id INTEGER REFERENCES users, note TEXT);
+		CREATE INDEX idx_orders_user ON orders (user_id, id);
+		CREATE TABLE tmp_orders (id INTEGER PRIMARY KEY);
+		CREATE TABLE logs (id INTEGER PRIMARY KEY);
+	`
+	if _, err := db.db.Exec(ddl); err != nil {
+		t.Fatalf("创建表失败: %v", err)
+	}
+	
+	tables, err := db.GetTables(context.Background(), TableFilter{Include: []string{"users", "orders", "tmp_orders"}, Exclude: []string{"tmp_*"}})
+	if err != nil {
+		t.Fatalf("获取表信息失败: %v", err)
+	}
+	if len(tables) != 2 || tables[0].Name != "orders" || tables[1].Name != "users" {
+		t.Fatalf("期望只读取 orders 和 users，实际为 %+v", tables)
+	}
+	
+	orders, users := tables[0], tables[1]
+	if len(orders.Columns) != 3 || len(users.Columns) != 3 {
+		t.Errorf("期望列按表分组，实际为 %+v 和 %+v", orders.Columns, users.Columns)
+	}
+	if len(orders.Indexes) != 1 || len(orders.Indexes[0].Columns) != 2 {
+		t.Errorf("期望 orders 有一个复合索引，实际为 %+v", orders.Indexes)
+	}
+	for _, index := range users.Indexes {
+		if index.Name == "idx_users_lower_email" {
+			t.Errorf("期望跳过表达式索引，实际为 %+v", users.Indexes)
+		}
+	}
+	if len(users.Indexes) != 2 {
+		t.Errorf("期望 users 有唯一约束和普通索引，实际为 %+v", users.Indexes)
+	}
+	if len(orders.ForeignKeys) != 1 || orders.ForeignKeys[0].RefColumns[0] != "id" || len(users.ForeignKeys) != 0 {
+		t.Errorf("期望 orders 的外键隐式引用 users 的主键，实际为 %+v", orders.ForeignKeys)
+	}
+	
+	columns, err := db.GetTableColumns(context.Background(), "USERS")
+	if err != nil || len(columns) != 3 {
+		t.Errorf("期望按表名读取单表的列且不区分大小写，实际为 %+v (%v)", columns, err)
+	}
+}
@@ -0,0 +1,107 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"go-mapper-gen/internal/config"
+	"go-mapper-gen/internal/database"
+)
+
+// EnumData 枚举类型模板数据
+type EnumData struct {
+	TypeName  string          // 生成的类型名，如 UsersStatus
+	Values    []string        // 可选值
+	Constants []EnumConstData // 可选值对应的常量
+	IsSet     bool            // 是否为 SET 类型，字段值为逗号分隔的多个可选值
+	Source    string          // 类型来源，如 users.status 或 PostgreSQL 枚举类型名
+	ZeroNull  bool            // zero_value 风格下有可空列使用该类型，零值写入为 NULL
+}
+
+// EnumConstData 枚举常量模板数据
+type EnumConstData struct {
+	Name  string // 常量名，如 UsersStatusActive
+	Value string // 带引号的 Go 字符串字面量
+}
+
+// buildEnums 收集所有枚举列，按表和列的顺序生成枚举类型数据。
+// PostgreSQL 的枚举类型可被多个列共用，只生成一次。
+func buildEnums(tables []database.Table, cfg *config.Config) []EnumData {
+	var enums []EnumData
+	seen := make(map[string]int)
+	for _, table := range tables {
+		for _, col := range table.Columns {
+			if len(col.EnumValues) == 0 {
+				continue
+			}
+			typeName := enumTypeName(table, col, cfg)
+			zeroNull := col.Nullable && cfg.Options.NullStyle == "zero_value"
+			if i, ok := seen[typeName]; ok {
+				enums[i].ZeroNull = enums[i].ZeroNull || zeroNull
+				continue
+			}
+			seen[typeName] = len(enums)
+			
+			enum := EnumData{
+				TypeName: typeName,
+				Values:   col.EnumValues,
+				IsSet:    isSetColumn(col),
+				Source:   table.QualifiedName() + "." + col.Name,
+				ZeroNull: zeroNull,
+			}
+			if col.EnumName != "" {
+				enum.Source = col.EnumName
+			}
+			enum.Constants = enumConstants(typeName, col.EnumValues)
+			enums = append(enums, enum)
+		}
+	}
+	return enums
+}
+
+// enumTypeName 返回枚举列的类型名。PostgreSQL 使用枚举类型名，MySQL 由结构体名和列名组成
+func enumTypeName(table database.Table, col database.Column, cfg *config.Config) string {
+	if col.EnumName != "" {
+		return toPascalCase(col.EnumName)
+	}
+	return structName(table, cfg) + toPascalCase(col.Name)
+}
+
+// isSetColumn 判断列是否为 MySQL SET 类型
+func isSetColumn(col database.Column) bool {
+	return col.Type == "set"
+}
+
+// enumConstants 为每个可选值生成常量名，名称冲突时追加序号
+func enumConstants(typeName string, values []string) []EnumConstData {
+	constants := make([]EnumConstData, 0, len(values))
+	used := make(map[string]bool)
+	for i, value := range values {
+		name := typeName + enumValueName(value)
+		if used[name] {
+			name = fmt.Sprintf("%s%d", name, i+1)
+		}
+		used[name] = true
+		constants = append(constants, EnumConstData{Name: name, Value: fmt.Sprintf("%q", value)})
+	}
+	return constants
+}
+
+// enumValueName 将可选值转换为常量名后缀，非字母数字字符作为单词分隔符
+func enumValueName(value string) string {
+	words := strings.FieldsFunc(value, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	if len(words) == 0 {
+		return "Empty"
+	}
+	
+	var name strings.Builder
+	for _, word := range words {
+		runes := []rune(word)
+		name.WriteRune(unicode.ToUpper(runes[0]))
+		name.WriteString(string(runes[1:]))
+	}
+	return name.String()
+}
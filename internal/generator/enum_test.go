@@ -0,0 +1,100 @@
+package generator
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"go-mapper-gen/internal/config"
+	"go-mapper-gen/internal/database"
+)
+
+func TestBuildEnums(t *testing.T) {
+	status := database.Column{Name: "status", Type: "USER-DEFINED", EnumName: "order_status", EnumValues: []string{"pending", "paid"}}
+	tables := []database.Table{
+		{Name: "t_users", Columns: []database.Column{
+			{Name: "id", Type: "int"},
+			{Name: "role", Type: "enum", EnumValues: []string{"admin", "it's me", "", "it-s-me"}},
+			{Name: "tags", Type: "set", EnumValues: []string{"a"}},
+		}},
+		{Name: "t_orders", Columns: []database.Column{status}},
+		{Name: "t_shipments", Columns: []database.Column{status}},
+	}
+	
+	expected := []EnumData{
+		{
+			TypeName: "UsersRole",
+			Values:   []string{"admin", "it's me", "", "it-s-me"},
+			Constants: []EnumConstData{
+				{Name: "UsersRoleAdmin", Value: `"admin"`},
+				{Name: "UsersRoleItSMe", Value: `"it's me"`},
+				{Name: "UsersRoleEmpty", Value: `""`},
+				{Name: "UsersRoleItSMe4", Value: `"it-s-me"`},
+			},
+			Source: "t_users.role",
+		},
+		{
+			TypeName:  "UsersTags",
+			Values:    []string{"a"},
+			Constants: []EnumConstData{{Name: "UsersTagsA", Value: `"a"`}},
+			IsSet:     true,
+			Source:    "t_users.tags",
+		},
+		{
+			TypeName: "OrderStatus",
+			Values:   []string{"pending", "paid"},
+			Constants: []EnumConstData{
+				{Name: "OrderStatusPending", Value: `"pending"`},
+				{Name: "OrderStatusPaid", Value: `"paid"`},
+			},
+			Source: "order_status",
+		},
+	}
+	
+	cfg := &config.Config{Tables: config.TablesConfig{Prefix: "t_"}}
+	if enums := buildEnums(tables, cfg); !reflect.DeepEqual(enums, expected) {
+		t.Errorf("枚举类型不正确:\n期望 %+v\n实际 %+v", expected, enums)
+	}
+	
+	if goType := fieldGoType(tables[0], tables[0].Columns[1], cfg); goType != "UsersRole" {
+		t.Errorf("期望字段类型为 UsersRole，实际为 %s", goType)
+	}
+	if goType := qualifyModelType("*UsersRole"); goType != "*model.UsersRole" {
+		t.Errorf("期望 DAO 中的类型为 *model.UsersRole，实际为 %s", goType)
+	}
+}
+
+func TestZeroValueEnums(t *testing.T) {
+	status := database.Column{Name: "status", Type: "USER-DEFINED", EnumName: "order_status", EnumValues: []string{"pending", "paid"}}
+	nullableStatus := status
+	nullableStatus.Nullable = true
+	tables := []database.Table{
+		{Name: "orders", Columns: []database.Column{status}},
+		{Name: "shipments", Columns: []database.Column{nullableStatus}},
+		{Name: "users", Columns: []database.Column{{Name: "role", Type: "enum", EnumValues: []string{"admin"}}}},
+	}
+	
+	cfg := &config.Config{Options: config.OptionsConfig{NullStyle: "zero_value"}}
+	enums := buildEnums(tables, cfg)
+	if len(enums) != 2 || !enums[0].ZeroNull || enums[1].ZeroNull {
+		t.Fatalf("期望可空列共用的枚举类型零值写入为 NULL，实际为 %+v", enums)
+	}
+	if goType := fieldGoType(tables[1], nullableStatus, cfg); goType != "OrderStatus" {
+		t.Errorf("期望 zero_value 风格的可空枚举字段类型为 OrderStatus，实际为 %s", goType)
+	}
+	
+	code, err := NewTypesGenerator(cfg).generateCode(TypesData{Package: "model", Enums: enums})
+	if err != nil {
+		t.Fatalf("生成类型代码失败: %v", err)
+	}
+	if !strings.Contains(code, "func (e OrderStatus) Value() (driver.Value, error) {\n\tif e == \"\" {\n\t\treturn nil, nil\n\t}") {
+		t.Errorf("期望 OrderStatus 的零值写入为 NULL:\n%s", code)
+	}
+	if !strings.Contains(code, "func (e UsersRole) Value() (driver.Value, error) {\n\tif !e.IsValid() {") {
+		t.Errorf("期望 UsersRole 的零值仍然校验:\n%s", code)
+	}
+	
+	if buildEnums(tables, &config.Config{})[0].ZeroNull {
+		t.Error("期望 pointer 风格的枚举类型不将零值写入为 NULL")
+	}
+}
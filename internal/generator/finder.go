@@ -0,0 +1,138 @@
+package generator
+
+import (
+	"fmt"
+	"go/token"
+	"strings"
+	
+	"go-mapper-gen/internal/database"
+)
+
+// FinderData 基于索引生成的查询方法模板数据
+type FinderData struct {
+	Name      string      // 方法名，如 GetByEmail、GetByUserIdAndCreatedAt
+	IndexName string      // 来源索引名
+	Fields    []FieldData // 查询条件字段，按索引列顺序排列
+	IsUnique  bool        // 唯一索引生成单条查询，普通索引生成列表查询
+}
+
+// reservedFinderNames 与 DAO 已有方法冲突的方法名
+var reservedFinderNames = map[string]bool{
+	"GetById":        true,
+	"GetByPage":      true,
+	"GetByCondition": true,
+	"GetByExample":   true,
+}
+
+// buildFinders 根据表索引生成查询方法，唯一索引优先，重名的方法只保留一个
+func buildFinders(table database.Table, fields []FieldData, key PrimaryKeyData) []FinderData {
+	fieldMap := make(map[string]FieldData, len(fields))
+	for _, field := range fields {
+		fieldMap[field.ColumnName] = field
+	}
+	
+	var finders []FinderData
+	seen := make(map[string]bool)
+	for _, unique := range []bool{true, false} {
+		for _, index := range table.Indexes {
+			if index.IsPrimary || index.IsUnique != unique || sameColumns(index.Columns, key.Fields) {
+				continue
+			}
+			
+			finder := FinderData{IndexName: index.Name, IsUnique: index.IsUnique}
+			var names []string
+			for _, column := range index.Columns {
+				field, ok := fieldMap[column]
+				if !ok {
+					finder.Fields = nil
+					break
+				}
+				// 查询参数不需要表达 NULL，去掉可空类型的包装
+				field.Type = nonNullGoType(field.Type)
+				finder.Fields = append(finder.Fields, field)
+				names = append(names, field.Name)
+			}
+			if len(finder.Fields) == 0 {
+				continue
+			}
+			
+			finder.Name = "GetBy" + strings.Join(names, "And")
+			if reservedFinderNames[finder.Name] || seen[finder.Name] {
+				continue
+			}
+			seen[finder.Name] = true
+			finders = append(finders, finder)
+		}
+	}
+	
+	return finders
+}
+
+// sameColumns 判断索引列是否与主键列完全一致
+func sameColumns(columns []string, keyFields []FieldData) bool {
+	if len(columns) != len(keyFields) {
+		return false
+	}
+	for i, field := range keyFields {
+		if columns[i] != field.ColumnName {
+			return false
+		}
+	}
+	return true
+}
+
+// predeclaredNames Go 预声明的标识符，作为参数名会遮蔽内置类型和函数
+var predeclaredNames = map[string]bool{
+	"any": true, "bool": true, "byte": true, "comparable": true, "complex64": true, "complex128": true,
+	"error": true, "float32": true, "float64": true, "int": true, "int8": true, "int16": true,
+	"int32": true, "int64": true, "rune": true, "string": true, "uint": true, "uint8": true,
+	"uint16": true, "uint32": true, "uint64": true, "uintptr": true,
+	"true": true, "false": true, "iota": true, "nil": true,
+	"append": true, "cap": true, "clear": true, "close": true, "complex": true, "copy": true,
+	"delete": true, "imag": true, "len": true, "make": true, "max": true, "min": true,
+	"new": true, "panic": true, "print": true, "println": true, "real": true, "recover": true,
+}
+
+// paramName 返回字段作为方法参数时的名称，与 Go 关键字或预声明标识符冲突时加 Param 后缀，如 typeParam
+func paramName(field FieldData) string {
+	name := toCamelCase(field.ColumnName)
+	if token.IsKeyword(name) || predeclaredNames[name] {
+		return name + "Param"
+	}
+	return name
+}
+
+// Params 返回方法参数声明，如 "userId int, createdAt time.Time"
+func (f FinderData) Params() string {
+	params := make([]string, 0, len(f.Fields))
+	for _, field := range f.Fields {
+		params = append(params, fmt.Sprintf("%s %s", paramName(field), field.Type))
+	}
+	return strings.Join(params, ", ")
+}
+
+// Where 生成 gobatis 查询条件，参数名与 DAO 方法参数一致
+func (f FinderData) Where() string {
+	conditions := make([]string, 0, len(f.Fields))
+	for _, field := range f.Fields {
+		conditions = append(conditions, fmt.Sprintf("%s = #{%s}", field.ColumnName, paramName(field)))
+	}
+	return strings.Join(conditions, " AND ")
+}
+
+// ParameterType 返回 XML 中的 parameterType，多个参数时使用 map
+func (f FinderData) ParameterType() string {
+	if len(f.Fields) == 1 {
+		return f.Fields[0].Type
+	}
+	return "map"
+}
+
+// Columns 返回查询条件列名
+func (f FinderData) Columns() string {
+	columns := make([]string, 0, len(f.Fields))
+	for _, field := range f.Fields {
+		columns = append(columns, field.ColumnName)
+	}
+	return strings.Join(columns, ", ")
+}
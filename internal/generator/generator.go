@@ -1,10 +1,16 @@
 package generator
 
 import (
+	"bufio"
+	"context"
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
+	"path"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"go-mapper-gen/internal/config"
 	"go-mapper-gen/internal/database"
@@ -12,27 +18,132 @@ import (
 
 // Generator 代码生成器
 type Generator struct {
-	config *config.Config
-	db     database.Database
+	config   *config.Config
+	db       database.Database
+	tables   []database.Table // 参与生成的表，用于解析表之间的关联关系
+	deadline time.Time        // 读取表结构的截止时间，由 database.timeout 计算，零值表示不限制
+	stats    Stats            // 最近一次 Generate 的统计
 }
 
-// New 创建新的生成器
-func New(cfg *config.Config) (*Generator, error) {
-	// 创建数据库连接
-	db, err := database.NewDatabase(cfg.Database.Driver, cfg.Database.DSN)
+// Stats 代码生成的统计信息
+type Stats struct {
+	Tables int // 生成代码的表数
+	Files  int // 写入输出目录的文件数
+}
+
+// New 创建新的生成器并连接表结构数据源，ctx 取消或超过 database.timeout 时中止连接
+func New(ctx context.Context, cfg *config.Config) (*Generator, error) {
+	g := &Generator{config: cfg}
+	
+	// 连接字符串在开始计时之前生成，终端输入密码的时间不计入超时
+	dsn, err := connectionDSN(cfg)
+	if err != nil {
+		return nil, err
+	}
+	
+	if cfg.Database.Timeout > 0 {
+		g.deadline = time.Now().Add(cfg.Database.Timeout)
+	}
+	
+	ctx, cancel := g.databaseContext(ctx)
+	defer cancel()
+	
+	db, err := openDatabase(ctx, cfg, dsn)
+	if err != nil {
+		return nil, err
+	}
+	g.db = db
+	
+	return g, nil
+}
+
+// databaseContext 返回访问数据源使用的上下文，连接和读取表结构共用同一个截止时间
+func (g *Generator) databaseContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if g.deadline.IsZero() {
+		return context.WithCancel(ctx)
+	}
+	return context.WithDeadline(ctx, g.deadline)
+}
+
+// connectionDSN 返回数据库驱动使用的连接字符串，快照、迁移目录和文件数据源不需要连接字符串
+func connectionDSN(cfg *config.Config) (string, error) {
+	if cfg.Database.Snapshot != "" || cfg.Database.Migrations != "" || isFileSource(cfg.Database.Driver) {
+		return "", nil
+	}
+	
+	dsn, err := cfg.Database.ConnectionDSN()
 	if err != nil {
-		return nil, fmt.Errorf("创建数据库连接失败: %w", err)
+		return "", fmt.Errorf("生成数据库连接字符串失败: %w", err)
+	}
+	return dsn, nil
+}
+
+// isFileSource 判断驱动是否为从本地文件读取表结构的数据源
+func isFileSource(driver string) bool {
+	factory, ok := database.Lookup(driver)
+	if !ok {
+		return false
+	}
+	_, ok = factory.(database.FileSource)
+	return ok
+}
+
+// openDatabase 根据配置创建并连接表结构数据源：快照直接读取文件，迁移目录应用到内存 SQLite，
+// 文件数据源（如 ddl 驱动）直接解析配置的文件
+func openDatabase(ctx context.Context, cfg *config.Config, dsn string) (database.Database, error) {
+	var db database.Database
+	retries := 0
+	if cfg.Database.Snapshot != "" {
+		db = database.NewSnapshot(cfg.Database.Snapshot)
+	} else if cfg.Database.Migrations != "" {
+		db = database.NewMigrations(cfg.Database.Migrations)
+	} else {
+		factory, ok := database.Lookup(cfg.Database.Driver)
+		if !ok {
+			return nil, fmt.Errorf("不支持的数据库驱动: %s", cfg.Database.Driver)
+		}
+		var err error
+		if source, ok := factory.(database.FileSource); ok {
+			db, err = source.OpenFiles(cfg.Database.Files, cfg.Database.Dialect)
+		} else {
+			// 创建数据库连接，只有数据库驱动的连接失败才重试
+			db, err = factory.Open(dsn, cfg.Database.Schemas)
+			retries = cfg.Database.ConnectRetries
+		}
+		if err != nil {
+			return nil, fmt.Errorf("创建数据库连接失败: %w", err)
+		}
 	}
 	
 	// 连接数据库
-	if err := db.Connect(); err != nil {
+	if err := connectWithRetry(ctx, db, retries); err != nil {
 		return nil, fmt.Errorf("连接数据库失败: %w", err)
 	}
 	
-	return &Generator{
-		config: cfg,
-		db:     db,
-	}, nil
+	return db, nil
+}
+
+// maxConnectBackoff 连接重试的最长等待时间
+const maxConnectBackoff = 10 * time.Second
+
+// connectWithRetry 连接数据源，失败时最多重试 retries 次，等待时间从 1 秒开始逐次翻倍；ctx 结束后不再重试
+func connectWithRetry(ctx context.Context, db database.Database, retries int) error {
+	backoff := time.Second
+	for attempt := 1; ; attempt++ {
+		err := db.Connect(ctx)
+		if err == nil || attempt > retries || ctx.Err() != nil {
+			return err
+		}
+		
+		// 进度输出到标准错误，inspect 命令的标准输出用于导出表结构
+		fmt.Fprintf(os.Stderr, "连接失败，%s 后重试 (%d/%d): %v\n", backoff, attempt, retries, err)
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%w (上次连接失败: %v)", ctx.Err(), err)
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, maxConnectBackoff)
+	}
 }
 
 // Close 关闭生成器
@@ -43,21 +154,107 @@ func (g *Generator) Close() error {
 	return nil
 }
 
-// Generate 执行代码生成
-func (g *Generator) Generate() error {
-	// 获取所有表
-	tables, err := g.db.GetTables()
+// Stats 返回最近一次成功执行 Generate 的统计信息
+func (g *Generator) Stats() Stats {
+	return g.stats
+}
+
+// Tables 获取按配置过滤后的表结构，没有匹配的表时返回空列表
+func (g *Generator) Tables(ctx context.Context) ([]database.Table, error) {
+	ctx, cancel := g.databaseContext(ctx)
+	defer cancel()
+	
+	// 过滤规则下推到数据源，未选中的表不会读取列、索引和外键
+	filteredTables, err := g.db.GetTables(ctx, g.tableFilter())
 	if err != nil {
-		return fmt.Errorf("获取表信息失败: %w", err)
+		return nil, fmt.Errorf("获取表信息失败: %w", err)
 	}
 	
-	// 过滤表
-	filteredTables := g.filterTables(tables)
+	return filteredTables, nil
+}
+
+// Generate 执行代码生成。代码先生成到输出目录旁的暂存目录，全部成功后再移入输出目录，
+// 中途出错或 ctx 被取消时输出目录保持不变
+func (g *Generator) Generate(ctx context.Context) error {
+	filteredTables, err := g.Tables(ctx)
+	if err != nil {
+		return err
+	}
 	if len(filteredTables) == 0 {
 		return fmt.Errorf("没有找到匹配的表")
 	}
 	
 	fmt.Printf("找到 %d 个表需要生成代码\n", len(filteredTables))
+	filteredTables = applyTypeMappings(filteredTables, g.config)
+	
+	outputDir := g.config.Output.Dir
+	importPath, err := outputImportPath(g.config.Output)
+	if err != nil {
+		return err
+	}
+	staging, err := newStagingDir(outputDir)
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(staging)
+	
+	// 导入路径按输出目录推断，切换到暂存目录前确定
+	baseConfig := g.config
+	defer func() { g.config = baseConfig }()
+	stagingConfig := *baseConfig
+	stagingConfig.Output.Dir = staging
+	stagingConfig.Output.ImportPath = importPath
+	g.config = &stagingConfig
+	
+	if err := g.generateSchemas(ctx, filteredTables); err != nil {
+		return err
+	}
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("生成已取消: %w", err)
+	}
+	
+	files, err := moveOutput(staging, outputDir)
+	if err != nil {
+		return err
+	}
+	g.stats = Stats{Tables: len(filteredTables), Files: files}
+	return nil
+}
+
+// generateSchemas 生成所有表的代码到 g.config.Output.Dir，split_schemas 时每个 schema 使用独立的子目录
+func (g *Generator) generateSchemas(ctx context.Context, filteredTables []database.Table) error {
+	if !g.config.Output.SplitSchemas {
+		return g.generateTables(ctx, filteredTables)
+	}
+	
+	// 按 schema 分包：每个 schema 使用独立的输出子目录，关联关系只在同一 schema 内建立
+	var schemas []string
+	groups := make(map[string][]database.Table)
+	for _, table := range filteredTables {
+		if _, ok := groups[table.Schema]; !ok {
+			schemas = append(schemas, table.Schema)
+		}
+		groups[table.Schema] = append(groups[table.Schema], table)
+	}
+	
+	baseConfig := g.config
+	defer func() { g.config = baseConfig }()
+	for _, schema := range schemas {
+		schemaConfig := *baseConfig
+		schemaConfig.Output.Dir = filepath.Join(baseConfig.Output.Dir, schema)
+		schemaConfig.Output.ImportPath = path.Join(baseConfig.Output.ImportPath, schema)
+		g.config = &schemaConfig
+		if err := g.generateTables(ctx, groups[schema]); err != nil {
+			return fmt.Errorf("生成 schema %s 的代码失败: %w", schema, err)
+		}
+	}
+	
+	return nil
+}
+
+// generateTables 为一组表生成代码到 g.config.Output.Dir，ctx 被取消时在当前表生成完后停止
+func (g *Generator) generateTables(ctx context.Context, tables []database.Table) error {
+	g.tables = tables
 	
 	// 创建输出目录
 	if err := g.createOutputDirs(); err != nil {
@@ -65,81 +262,213 @@ func (g *Generator) Generate() error {
 	}
 	
 	// 生成代码
-	for _, table := range filteredTables {
-		fmt.Printf("正在生成表 %s 的代码...\n", table.Name)
+	for _, table := range tables {
+		if err := ctx.Err(); err != nil {
+			return fmt.Errorf("生成已取消: %w", err)
+		}
+		fmt.Printf("正在生成表 %s 的代码...\n", table.QualifiedName())
 		
 		// 生成结构体
 		if err := g.generateStruct(table); err != nil {
-			return fmt.Errorf("生成表 %s 的结构体失败: %w", table.Name, err)
+			return fmt.Errorf("生成表 %s 的结构体失败: %w", table.QualifiedName(), err)
 		}
 		
 		// 生成 DAO 和 XML 映射文件
 		if g.config.Options.GenerateDAO {
 			if err := g.generateDAO(table); err != nil {
-				return fmt.Errorf("生成表 %s 的 DAO 失败: %w", table.Name, err)
+				return fmt.Errorf("生成表 %s 的 DAO 失败: %w", table.QualifiedName(), err)
 			}
 		}
 		
 		// 生成 SQL
 		if g.config.Options.GenerateSQL {
 			if err := g.generateSQL(table); err != nil {
-				return fmt.Errorf("生成表 %s 的 SQL 失败: %w", table.Name, err)
+				return fmt.Errorf("生成表 %s 的 SQL 失败: %w", table.QualifiedName(), err)
 			}
 		}
 	}
 	
+	// 生成枚举等模型包共用的类型
+	if err := NewTypesGenerator(g.config).Generate(tables); err != nil {
+		return fmt.Errorf("生成模型类型失败: %w", err)
+	}
+	
 	return nil
 }
 
-// filterTables 过滤表
-func (g *Generator) filterTables(tables []database.Table) []database.Table {
-	var filtered []database.Table
+// tableFilter 根据配置返回表过滤规则，由数据源在读取表结构时应用
+func (g *Generator) tableFilter() database.TableFilter {
+	return database.TableFilter{
+		Include: g.config.Tables.Include,
+		Exclude: g.config.Tables.Exclude,
+		Prefix:  g.config.Tables.Prefix,
+	}
+}
+
+// outputImportPath 返回输出目录的 Go 导入路径：优先使用 output.import_path，
+// 否则由包含输出目录的 go.mod 的模块路径加上输出目录的相对路径得到
+func outputImportPath(output config.OutputConfig) (string, error) {
+	if output.ImportPath != "" {
+		return output.ImportPath, nil
+	}
 	
-	for _, table := range tables {
-		// 检查包含列表
-		if len(g.config.Tables.Include) > 0 {
-			found := false
-			for _, include := range g.config.Tables.Include {
-				if table.Name == include {
-					found = true
-					break
-				}
+	dir, err := filepath.Abs(output.Dir)
+	if err != nil {
+		return "", fmt.Errorf("解析输出目录失败: %w", err)
+	}
+	for root := dir; ; root = filepath.Dir(root) {
+		module, err := readModulePath(filepath.Join(root, "go.mod"))
+		if err != nil {
+			return "", err
+		}
+		if module != "" {
+			rel, err := filepath.Rel(root, dir)
+			if err != nil {
+				return "", fmt.Errorf("解析输出目录失败: %w", err)
+			}
+			return path.Join(module, filepath.ToSlash(rel)), nil
+		}
+		if filepath.Dir(root) == root {
+			break
+		}
+	}
+	
+	fmt.Printf("未找到输出目录 %s 所在模块的 go.mod，model 包按相对路径导入，可通过 output.import_path 指定\n", output.Dir)
+	return filepath.ToSlash(filepath.Clean(output.Dir)), nil
+}
+
+// readModulePath 读取 go.mod 中的模块路径，文件不存在时返回空字符串
+func readModulePath(goMod string) (string, error) {
+	file, err := os.Open(goMod)
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("读取 %s 失败: %w", goMod, err)
+	}
+	defer file.Close()
+	
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) >= 2 && fields[0] == "module" {
+			return strings.Trim(fields[1], `"`), nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", fmt.Errorf("读取 %s 失败: %w", goMod, err)
+	}
+	return "", fmt.Errorf("%s 中没有模块路径", goMod)
+}
+
+// newStagingDir 在输出目录的上级目录中创建暂存目录，与输出目录位于同一文件系统，生成完成后可以直接移动文件
+func newStagingDir(outputDir string) (string, error) {
+	parent := filepath.Dir(filepath.Clean(outputDir))
+	if err := os.MkdirAll(parent, 0755); err != nil {
+		return "", fmt.Errorf("创建目录 %s 失败: %w", parent, err)
+	}
+	
+	staging, err := os.MkdirTemp(parent, "."+filepath.Base(filepath.Clean(outputDir))+"-*")
+	if err != nil {
+		return "", fmt.Errorf("创建暂存目录失败: %w", err)
+	}
+	return staging, nil
+}
+
+// movedFile 移入输出目录的文件，backup 为被覆盖文件在备份目录中的路径，没有覆盖文件时为空
+type movedFile struct {
+	target string
+	backup string
+}
+
+// moveOutput 将暂存目录中生成的文件移入输出目录，覆盖同名文件，输出目录中的其他文件保持不变；返回移动的文件数。
+// 被覆盖的文件先移入备份目录，中途出错时撤销已移入的文件并恢复被覆盖的文件，输出目录保持原样
+func moveOutput(staging, outputDir string) (int, error) {
+	outputDir = filepath.Clean(outputDir)
+	backup, err := os.MkdirTemp(filepath.Dir(outputDir), "."+filepath.Base(outputDir)+"-backup-*")
+	if err != nil {
+		return 0, fmt.Errorf("创建备份目录失败: %w", err)
+	}
+	defer os.RemoveAll(backup)
+	
+	var moved []movedFile
+	var createdDirs []string
+	err = filepath.WalkDir(staging, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		
+		rel, err := filepath.Rel(staging, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(outputDir, rel)
+		
+		if entry.IsDir() {
+			if _, err := os.Stat(target); os.IsNotExist(err) {
+				createdDirs = append(createdDirs, target)
 			}
-			if !found {
-				continue
+			if err := os.MkdirAll(target, 0755); err != nil {
+				return fmt.Errorf("创建目录 %s 失败: %w", target, err)
 			}
+			return nil
 		}
 		
-		// 检查排除列表
-		excluded := false
-		for _, exclude := range g.config.Tables.Exclude {
-			if strings.Contains(exclude, "*") {
-				// 支持通配符
-				pattern := strings.ReplaceAll(exclude, "*", "")
-				if strings.Contains(table.Name, pattern) {
-					excluded = true
-					break
-				}
-			} else if table.Name == exclude {
-				excluded = true
-				break
+		file := movedFile{target: target}
+		if _, err := os.Lstat(target); err == nil {
+			file.backup = filepath.Join(backup, rel)
+			if err := os.MkdirAll(filepath.Dir(file.backup), 0755); err != nil {
+				return fmt.Errorf("创建备份目录失败: %w", err)
+			}
+			if err := os.Rename(target, file.backup); err != nil {
+				return fmt.Errorf("备份文件 %s 失败: %w", target, err)
+			}
+		}
+		if err := os.Rename(path, target); err != nil {
+			if file.backup != "" {
+				os.Rename(file.backup, target)
 			}
+			return fmt.Errorf("移动文件到 %s 失败: %w", target, err)
 		}
-		if excluded {
+		moved = append(moved, file)
+		return nil
+	})
+	if err != nil {
+		if rollbackErr := rollbackOutput(moved, createdDirs); rollbackErr != nil {
+			return 0, fmt.Errorf("%w; 恢复输出目录失败: %v", err, rollbackErr)
+		}
+		return 0, err
+	}
+	return len(moved), nil
+}
+
+// rollbackOutput 按相反顺序删除已移入的文件、恢复被覆盖的文件，并删除新建的空目录
+func rollbackOutput(moved []movedFile, createdDirs []string) error {
+	var errs []error
+	for i := len(moved) - 1; i >= 0; i-- {
+		file := moved[i]
+		if err := os.Remove(file.target); err != nil {
+			errs = append(errs, err)
 			continue
 		}
-		
-		// 检查前缀
-		if g.config.Tables.Prefix != "" {
-			if !strings.HasPrefix(table.Name, g.config.Tables.Prefix) {
-				continue
+		if file.backup != "" {
+			if err := os.Rename(file.backup, file.target); err != nil {
+				errs = append(errs, err)
 			}
 		}
-		
-		filtered = append(filtered, table)
 	}
-	
-	return filtered
+	for i := len(createdDirs) - 1; i >= 0; i-- {
+		os.Remove(createdDirs[i])
+	}
+	return errors.Join(errs...)
+}
+
+// outputPath 返回生成文件相对输出目录的路径，用于打印生成进度；生成时实际写入的是暂存目录
+func outputPath(cfg *config.Config, path string) string {
+	if rel, err := filepath.Rel(cfg.Output.Dir, path); err == nil {
+		return rel
+	}
+	return path
 }
 
 // createOutputDirs 创建输出目录
@@ -169,6 +498,7 @@ func (g *Generator) createOutputDirs() error {
 // generateStruct 生成结构体
 func (g *Generator) generateStruct(table database.Table) error {
 	structGen := NewStructGenerator(g.config)
+	structGen.SetTables(g.tables)
 	return structGen.Generate(table)
 }
 
@@ -176,12 +506,14 @@ func (g *Generator) generateStruct(table database.Table) error {
 func (g *Generator) generateDAO(table database.Table) error {
 	// 生成 gobatis DAO 接口
 	gobatisDAOGen := NewGobatisDAOGenerator(g.config)
+	gobatisDAOGen.SetTables(g.tables)
 	if err := gobatisDAOGen.Generate(table, g.config.Output.Dir); err != nil {
 		return err
 	}
 	
 	// 生成 gobatis XML 映射文件
 	gobatisXMLGen := NewGobatisXMLGenerator(g.config)
+	gobatisXMLGen.SetTables(g.tables)
 	return gobatisXMLGen.Generate(table)
 }
 
@@ -189,11 +521,13 @@ func (g *Generator) generateDAO(table database.Table) error {
 func (g *Generator) generateGobatisDAO(cfg *config.Config, tables []database.Table) error {
 	for _, table := range tables {
 		gobatisDAOGen := NewGobatisDAOGenerator(cfg)
+		gobatisDAOGen.SetTables(tables)
 		if err := gobatisDAOGen.Generate(table, cfg.Output.Dir); err != nil {
 			return fmt.Errorf("生成表 %s 的 Gobatis DAO 失败: %w", table.Name, err)
 		}
 		
 		gobatisXMLGen := NewGobatisXMLGenerator(cfg)
+		gobatisXMLGen.SetTables(tables)
 		if err := gobatisXMLGen.Generate(table); err != nil {
 			return fmt.Errorf("生成表 %s 的 Gobatis XML 失败: %w", table.Name, err)
 		}
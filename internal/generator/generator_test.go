@@ -0,0 +1,262 @@
+package generator
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"go-mapper-gen/internal/config"
+	"go-mapper-gen/internal/database"
+)
+
+func TestGenerateStaging(t *testing.T) {
+	dir := t.TempDir()
+	tables := []database.Table{{Name: "users", Columns: []database.Column{
+		{Name: "id", Type: "int", GoType: "int", IsPrimaryKey: true},
+		{Name: "name", Type: "varchar", GoType: "string"},
+	}}}
+	data, err := json.Marshal(tables)
+	if err != nil {
+		t.Fatal(err)
+	}
+	snapshot := filepath.Join(dir, "schema.json")
+	if err := os.WriteFile(snapshot, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	
+	outputDir := filepath.Join(dir, "generated")
+	cfg := &config.Config{
+		Database: config.DatabaseConfig{Snapshot: snapshot},
+		Output:   config.OutputConfig{Dir: outputDir, Package: "model"},
+		Options:  config.OptionsConfig{GenerateDAO: true, GenerateSQL: true, NamespaceFormat: "{struct}DAO", NullStyle: "pointer"},
+	}
+	gen, err := New(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("创建生成器失败: %v", err)
+	}
+	defer gen.Close()
+	
+	// 取消后不写入输出目录，也不留下暂存目录
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := gen.Generate(ctx); err == nil {
+		t.Fatal("期望取消后生成失败")
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("期望取消后只剩快照文件，实际为 %v", entries)
+	}
+	
+	// 成功时移入输出目录，保留输出目录中的其他文件
+	if err := os.MkdirAll(filepath.Join(outputDir, "model"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	custom := filepath.Join(outputDir, "model", "custom.go")
+	if err := os.WriteFile(custom, []byte("package model\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := gen.Generate(context.Background()); err != nil {
+		t.Fatalf("生成失败: %v", err)
+	}
+	for _, file := range []string{"model/users.go", "model/custom.go", "dao/users_dao.go", "mapper/users_mapper.xml", "sql/users.sql"} {
+		if _, err := os.Stat(filepath.Join(outputDir, file)); err != nil {
+			t.Errorf("期望输出目录包含 %s: %v", file, err)
+		}
+	}
+	if stats := gen.Stats(); stats.Tables != 1 || stats.Files != 4 {
+		t.Errorf("期望生成 1 个表的 4 个文件，实际为 %+v", stats)
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 2 {
+		t.Errorf("期望生成后不留下暂存目录，实际为 %v", entries)
+	}
+}
+
+func TestTablesEmpty(t *testing.T) {
+	dir := t.TempDir()
+	snapshot := filepath.Join(dir, "schema.json")
+	if err := os.WriteFile(snapshot, []byte("[]"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	
+	cfg := &config.Config{
+		Database: config.DatabaseConfig{Snapshot: snapshot},
+		Output:   config.OutputConfig{Dir: filepath.Join(dir, "generated"), Package: "model"},
+	}
+	gen, err := New(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("创建生成器失败: %v", err)
+	}
+	defer gen.Close()
+	
+	// 读取表结构允许空列表，供 diff 和 migrate 与空库比较；生成代码时仍报错
+	tables, err := gen.Tables(context.Background())
+	if err != nil || len(tables) != 0 {
+		t.Fatalf("期望返回空表结构，实际为 %v, %v", tables, err)
+	}
+	if err := gen.Generate(context.Background()); err == nil || !strings.Contains(err.Error(), "没有找到匹配的表") {
+		t.Errorf("期望生成时报告没有匹配的表，实际为 %v", err)
+	}
+}
+
+func TestOutputImportPath(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte("// 示例模块\nmodule github.com/acme/app\n\ngo 1.23\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	
+	tests := []struct {
+		output   config.OutputConfig
+		expected string
+	}{
+		{config.OutputConfig{Dir: filepath.Join(dir, "internal", "generated")}, "github.com/acme/app/internal/generated"},
+		{config.OutputConfig{Dir: dir}, "github.com/acme/app"},
+		{config.OutputConfig{Dir: dir, ImportPath: "example.com/models"}, "example.com/models"},
+	}
+	for _, tt := range tests {
+		importPath, err := outputImportPath(tt.output)
+		if err != nil {
+			t.Fatalf("推断导入路径失败: %v", err)
+		}
+		if importPath != tt.expected {
+			t.Errorf("期望 %s 的导入路径为 %s，实际为 %s", tt.output.Dir, tt.expected, importPath)
+		}
+	}
+}
+
+func TestGenerateModelImportPath(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module github.com/acme/app\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	tables := []database.Table{{Schema: "billing", Name: "invoices", Columns: []database.Column{
+		{Name: "id", Type: "int", GoType: "int", IsPrimaryKey: true},
+	}}}
+	data, err := json.Marshal(tables)
+	if err != nil {
+		t.Fatal(err)
+	}
+	snapshot := filepath.Join(dir, "schema.json")
+	if err := os.WriteFile(snapshot, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	
+	outputDir := filepath.Join(dir, "generated")
+	cfg := &config.Config{
+		Database: config.DatabaseConfig{Snapshot: snapshot},
+		Output:   config.OutputConfig{Dir: outputDir, Package: "model", SplitSchemas: true},
+		Options:  config.OptionsConfig{GenerateDAO: true, NamespaceFormat: "{struct}DAO", NullStyle: "pointer"},
+	}
+	gen, err := New(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("创建生成器失败: %v", err)
+	}
+	defer gen.Close()
+	if err := gen.Generate(context.Background()); err != nil {
+		t.Fatalf("生成失败: %v", err)
+	}
+	
+	// 按 schema 分包时从 schema 子目录导入 model 包，导入路径不受暂存目录影响
+	code, err := os.ReadFile(filepath.Join(outputDir, "billing", "dao", "invoices_dao.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := `model "github.com/acme/app/generated/billing/model"`; !strings.Contains(string(code), expected) {
+		t.Errorf("期望 DAO 导入 %s:\n%s", expected, code)
+	}
+}
+
+func TestGenerateTypesTable(t *testing.T) {
+	dir := t.TempDir()
+	tables := []database.Table{{Name: "types", Columns: []database.Column{
+		{Name: "id", Type: "int", GoType: "int", IsPrimaryKey: true},
+		{Name: "kind", Type: "enum", GoType: "string", EnumValues: []string{"a", "b"}},
+	}}}
+	data, err := json.Marshal(tables)
+	if err != nil {
+		t.Fatal(err)
+	}
+	snapshot := filepath.Join(dir, "schema.json")
+	if err := os.WriteFile(snapshot, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	
+	// 名为 types 的表的结构体文件不能被类型文件覆盖
+	outputDir := filepath.Join(dir, "generated")
+	cfg := &config.Config{
+		Database: config.DatabaseConfig{Snapshot: snapshot},
+		Output:   config.OutputConfig{Dir: outputDir, Package: "model"},
+		Options:  config.OptionsConfig{NamespaceFormat: "{struct}DAO"},
+	}
+	gen, err := New(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("创建生成器失败: %v", err)
+	}
+	defer gen.Close()
+	if err := gen.Generate(context.Background()); err != nil {
+		t.Fatalf("生成失败: %v", err)
+	}
+	
+	structCode, err := os.ReadFile(filepath.Join(outputDir, "model", "types.go"))
+	if err != nil {
+		t.Fatalf("读取结构体文件失败: %v", err)
+	}
+	if !strings.Contains(string(structCode), "type Types struct") {
+		t.Errorf("结构体文件被覆盖:\n%s", structCode)
+	}
+	typesCode, err := os.ReadFile(filepath.Join(outputDir, "model", "zz_generated_types.go"))
+	if err != nil {
+		t.Fatalf("读取类型文件失败: %v", err)
+	}
+	if !strings.Contains(string(typesCode), "TypesKind") {
+		t.Errorf("类型文件缺少枚举类型:\n%s", typesCode)
+	}
+}
+
+func TestMoveOutputRollback(t *testing.T) {
+	dir := t.TempDir()
+	staging := filepath.Join(dir, "staging")
+	outputDir := filepath.Join(dir, "generated")
+	files := map[string]string{
+		filepath.Join(staging, "dao", "users_dao.go"):  "new dao",
+		filepath.Join(staging, "model", "users.go"):    "new model",
+		filepath.Join(staging, "sql", "users.sql"):     "new sql",
+		filepath.Join(outputDir, "model", "users.go"):  "old model",
+		filepath.Join(outputDir, "model", "custom.go"): "custom",
+	}
+	for path, content := range files {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// sql 在输出目录中是文件，移动到最后一个目录时失败
+	if err := os.WriteFile(filepath.Join(outputDir, "sql"), []byte("not a dir"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	
+	if _, err := moveOutput(staging, outputDir); err == nil {
+		t.Fatal("期望移动失败")
+	}
+	
+	// 已移入的文件被撤销，被覆盖的文件恢复，新建的目录被删除
+	for path, expected := range map[string]string{"model/users.go": "old model", "model/custom.go": "custom", "sql": "not a dir"} {
+		content, err := os.ReadFile(filepath.Join(outputDir, path))
+		if err != nil || string(content) != expected {
+			t.Errorf("期望 %s 恢复为 %q，实际为 %q (%v)", path, expected, content, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(outputDir, "dao")); !os.IsNotExist(err) {
+		t.Errorf("期望删除新建的 dao 目录: %v", err)
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 2 {
+		t.Errorf("期望不留下备份目录，实际为 %v", entries)
+	}
+}
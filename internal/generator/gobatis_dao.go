@@ -3,7 +3,9 @@ package generator
 import (
 	"fmt"
 	"os"
+	"path"
 	"path/filepath"
+	"sort"
 	"strings"
 	"text/template"
 
@@ -14,6 +16,7 @@ import (
 // GobatisDAOGenerator gobatis DAO 生成器
 type GobatisDAOGenerator struct {
 	config *config.Config
+	tables []database.Table
 }
 
 // NewGobatisDAOGenerator 创建 gobatis DAO 生成器
@@ -29,11 +32,24 @@ type GobatisDAOData struct {
 	StructName      string
 	TableName       string
 	PrimaryKey      FieldData
+	Key             PrimaryKeyData
+	KeyParam        string // 主键参数声明，如 "id int" 或 "key model.OrderItemsKey"
+	KeysParam       string // 主键列表参数声明，如 "ids []int"
 	Fields          []FieldData
+	Finders         []FinderData
+	Relations       []RelationData
+	Imports         []string
 	HasPrimaryKey   bool
+	ReadOnly        bool // 视图只生成查询方法
+	Refreshable     bool // 物化视图生成 Refresh 方法
 	GenerateExample bool
 }
 
+// SetTables 设置参与生成的表，用于解析外键关联
+func (gdg *GobatisDAOGenerator) SetTables(tables []database.Table) {
+	gdg.tables = tables
+}
+
 // Generate 生成 Gobatis DAO 代码
 func (gdg *GobatisDAOGenerator) Generate(table database.Table, outputDir string) error {
 	// 准备模板数据
@@ -62,17 +78,17 @@ func (gdg *GobatisDAOGenerator) Generate(table database.Table, outputDir string)
 
 // prepareTemplateData 准备模板数据
 func (gdg *GobatisDAOGenerator) prepareTemplateData(table database.Table) GobatisDAOData {
-	structName := toPascalCase(removeTablePrefix(table.Name, gdg.config.Tables.Prefix))
-	
-	// 构建正确的模块路径
-	modelPackage := "go-mapper-gen/examples/generated/model"
+	structName := structName(table, gdg.config)
 	
+	// 模型位于输出目录的 model 子目录，按 schema 分包时输出目录已指向 schema 子目录
 	data := GobatisDAOData{
 		Package:         "dao",
-		ModelPackage:    modelPackage,
+		ModelPackage:    path.Join(gdg.config.Output.ImportPath, "model"),
 		DAOName:         structName + "DAO",
 		StructName:      structName,
-		TableName:       table.Name,
+		TableName:       table.QualifiedName(),
+		ReadOnly:        table.IsView(),
+		Refreshable:     table.Kind == database.KindMaterializedView,
 		GenerateExample: gdg.config.Options.GenerateExample,
 	}
 	
@@ -80,34 +96,109 @@ func (gdg *GobatisDAOGenerator) prepareTemplateData(table database.Table) Gobati
 	for _, col := range table.Columns {
 		field := FieldData{
 			Name:         toPascalCase(col.Name),
-			Type:         col.GoType,
+			Type:         qualifyModelType(fieldGoType(table, col, gdg.config)),
+			ColumnName:   col.Name,
+			Import:       col.GoImport,
 			Comment:      col.Comment,
 			IsPrimaryKey: col.IsPrimaryKey,
-		}
-		
-		if col.IsPrimaryKey {
-			data.PrimaryKey = field
-			data.HasPrimaryKey = true
+			IsAutoIncr:   col.IsAutoIncr,
+			Nullable:     col.Nullable,
+			IsGenerated:  col.Generated != "",
+			Length:       col.Length,
+			Precision:    col.Precision,
+			Scale:        col.Scale,
 		}
 		
 		data.Fields = append(data.Fields, field)
 	}
 	
+	// 处理主键，复合主键使用生成的 Key 结构体作为参数
+	data.Key = newPrimaryKeyData(structName, data.Fields)
+	data.HasPrimaryKey = data.Key.Exists()
+	if data.Key.IsComposite() {
+		data.KeyParam = fmt.Sprintf("key model.%s", data.Key.StructName)
+		data.KeysParam = fmt.Sprintf("keys []model.%s", data.Key.StructName)
+	} else if data.HasPrimaryKey {
+		data.PrimaryKey = data.Key.Fields[0]
+		paramName := strings.ToLower(data.PrimaryKey.Name)
+		data.KeyParam = fmt.Sprintf("%s %s", paramName, data.PrimaryKey.Type)
+		data.KeysParam = fmt.Sprintf("%ss []%s", paramName, data.PrimaryKey.Type)
+		// 主键类型出现在方法参数中，映射为外部类型时需要导入
+		data.Imports = appendFieldImports(data.Imports, data.PrimaryKey)
+	}
+	
+	// 根据外键生成联表查询方法，需要主键定位本表记录
+	if gdg.config.Options.GenerateRelations && data.HasPrimaryKey {
+		data.Relations = buildRelations(table, gdg.tables, gdg.config)
+	}
+	
+	// 根据唯一索引和普通索引生成查询方法
+	data.Finders = buildFinders(table, data.Fields, data.Key)
+	for _, finder := range data.Finders {
+		for _, field := range finder.Fields {
+			data.Imports = appendFieldImports(data.Imports, field)
+		}
+	}
+	
 	return data
 }
 
+// appendFieldImports 追加字段类型需要的包导入，包括类型映射配置声明的导入
+func appendFieldImports(imports []string, field FieldData) []string {
+	imports = appendTypeImport(imports, field.Type)
+	if field.Import != "" {
+		imports = appendImport(imports, field.Import)
+	}
+	return imports
+}
+
+// typeImports 类型中出现的包名前缀及其导入路径
+var typeImports = []struct {
+	prefix string
+	path   string
+}{
+	{"time.Time", "time"},
+	{"json.RawMessage", "encoding/json"},
+	{"pq.", "github.com/lib/pq"},
+	{"sql.Null", "database/sql"},
+}
+
+// appendTypeImport 根据类型追加需要的包导入，sql.Null[time.Time] 等组合类型需要多个导入
+func appendTypeImport(imports []string, goType string) []string {
+	for _, imp := range typeImports {
+		if strings.Contains(goType, imp.prefix) {
+			imports = appendImport(imports, imp.path)
+		}
+	}
+	return imports
+}
+
+// appendImport 追加导入路径并保持有序，已存在时忽略
+func appendImport(imports []string, path string) []string {
+	for _, imp := range imports {
+		if imp == path {
+			return imports
+		}
+	}
+	imports = append(imports, path)
+	sort.Strings(imports)
+	return imports
+}
+
 // generateInterfaceCode 生成接口代码
 func (gdg *GobatisDAOGenerator) generateInterfaceCode(data GobatisDAOData) (string, error) {
 	tmpl := `package {{ .Package }}
 
-import (
+import ({{ range .Imports }}
+	"{{ . }}"{{ end }}
 	model "{{ .ModelPackage }}"{{ if .GenerateExample }}
 	"gobatis/core/example"{{ end }}
 )
 
-// {{ .DAOName }} {{ .StructName }} 数据访问接口
+// {{ .DAOName }} {{ .StructName }} 数据访问接口{{ if .ReadOnly }}（视图，只读）{{ end }}
 // 严格遵循 GoBatis 框架方法命名规则和返回值规范
 type {{ .DAOName }} interface {
+{{- if not .ReadOnly }}
 	// 插入方法 (INSERT) - 返回影响行数
 	// Insert 插入单个{{ .StructName }}记录
 	Insert(record *model.{{ .StructName }}) (int64, error)
@@ -123,19 +214,33 @@ type {{ .DAOName }} interface {
 	
 	// Save 保存{{ .StructName }}记录 (Insert 的别名)
 	Save(record *model.{{ .StructName }}) (int64, error)
+{{- end }}
 
 {{ if .HasPrimaryKey }}
 	// 查询方法 (SELECT) - 返回查询结果
 	// GetById 根据主键获取{{ .StructName }}
-	GetById({{ toLower .PrimaryKey.Name }} {{ .PrimaryKey.Type }}) (*model.{{ .StructName }}, error)
+	GetById({{ .KeyParam }}) (*model.{{ .StructName }}, error)
 	
 	// FindById 根据主键查找{{ .StructName }} (GetById 的别名)
-	FindById({{ toLower .PrimaryKey.Name }} {{ .PrimaryKey.Type }}) (*model.{{ .StructName }}, error)
+	FindById({{ .KeyParam }}) (*model.{{ .StructName }}, error)
 	
 	// SelectById 根据主键选择{{ .StructName }} (GetById 的别名)
-	SelectById({{ toLower .PrimaryKey.Name }} {{ .PrimaryKey.Type }}) (*model.{{ .StructName }}, error)
+	SelectById({{ .KeyParam }}) (*model.{{ .StructName }}, error)
 {{ end }}
 
+{{ range .Finders }}
+{{- if .IsUnique }}
+	// {{ .Name }} 根据唯一索引 {{ .IndexName }} 获取{{ $.StructName }}
+	{{ .Name }}({{ .Params }}) (*model.{{ $.StructName }}, error)
+{{ else }}
+	// {{ .Name }} 根据索引 {{ .IndexName }} 获取{{ $.StructName }}记录列表
+	{{ .Name }}({{ .Params }}) ([]*model.{{ $.StructName }}, error)
+{{ end }}
+{{- end }}
+{{- range .Relations }}
+	// {{ .MethodName }} 根据主键获取{{ $.StructName }}，并加载关联的{{ .StructName }}（外键 {{ .ForeignKey }}）
+	{{ .MethodName }}({{ $.KeyParam }}) (*model.{{ $.StructName }}, error)
+{{ end }}
 	// GetAll 获取所有{{ .StructName }}记录
 	GetAll() ([]*model.{{ .StructName }}, error)
 	
@@ -185,8 +290,14 @@ type {{ .DAOName }} interface {
 {{ if .HasPrimaryKey }}
 	// 存在性检查方法 - 返回布尔值
 	// GetExistsById 检查指定主键的{{ .StructName }}记录是否存在
-	GetExistsById({{ toLower .PrimaryKey.Name }} {{ .PrimaryKey.Type }}) (bool, error)
+	GetExistsById({{ .KeyParam }}) (bool, error)
+{{ end }}
+{{- if .Refreshable }}
+	// 刷新方法 - 返回影响行数
+	// Refresh 刷新物化视图{{ .StructName }}
+	Refresh() (int64, error)
 {{ end }}
+{{- if not .ReadOnly }}
 
 	// 更新方法 (UPDATE) - 返回影响行数
 {{ if .HasPrimaryKey }}
@@ -206,16 +317,16 @@ type {{ .DAOName }} interface {
 	// 删除方法 (DELETE) - 返回影响行数
 {{ if .HasPrimaryKey }}
 	// DeleteById 根据主键删除{{ .StructName }}
-	DeleteById({{ toLower .PrimaryKey.Name }} {{ .PrimaryKey.Type }}) (int64, error)
+	DeleteById({{ .KeyParam }}) (int64, error)
 	
 	// RemoveById 根据主键移除{{ .StructName }} (DeleteById 的别名)
-	RemoveById({{ toLower .PrimaryKey.Name }} {{ .PrimaryKey.Type }}) (int64, error)
+	RemoveById({{ .KeyParam }}) (int64, error)
 	
 	// DeleteByIds 根据主键列表批量删除{{ .StructName }}
-	DeleteByIds({{ toLower .PrimaryKey.Name }}s []{{ .PrimaryKey.Type }}) (int64, error)
+	DeleteByIds({{ .KeysParam }}) (int64, error)
 	
 	// RemoveByIds 根据主键列表批量移除{{ .StructName }} (DeleteByIds 的别名)
-	RemoveByIds({{ toLower .PrimaryKey.Name }}s []{{ .PrimaryKey.Type }}) (int64, error)
+	RemoveByIds({{ .KeysParam }}) (int64, error)
 {{ end }}
 
 	// DeleteByCondition 根据条件删除{{ .StructName }}记录
@@ -223,6 +334,7 @@ type {{ .DAOName }} interface {
 	
 	// RemoveByCondition 根据条件移除{{ .StructName }}记录 (DeleteByCondition 的别名)
 	RemoveByCondition(condition map[string]interface{}) (int64, error)
+{{- end }}
 
 {{ if .GenerateExample }}
 	// Example 查询方法 - 支持 GoBatis Example 功能
@@ -243,6 +355,7 @@ type {{ .DAOName }} interface {
 	
 	// CountByExample 根据 Example 条件统计{{ .StructName }}记录数
 	CountByExample(example *example.Example) (int64, error)
+{{- if not .ReadOnly }}
 	
 	// UpdateByExample 根据 Example 条件更新{{ .StructName }}记录
 	UpdateByExample(record *model.{{ .StructName }}, example *example.Example) (int64, error)
@@ -259,6 +372,7 @@ type {{ .DAOName }} interface {
 	// RemoveByExample 根据 Example 条件移除{{ .StructName }}记录 (DeleteByExample 的别名)
 	RemoveByExample(example *example.Example) (int64, error)
 {{ end }}
+{{- end }}
 }
 `
 	
@@ -0,0 +1,186 @@
+package generator
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"path"
+	"regexp"
+	"strings"
+	"testing"
+
+	"go-mapper-gen/internal/config"
+	"go-mapper-gen/internal/database"
+)
+
+func TestGobatisDAOCompositeKey(t *testing.T) {
+	cfg := &config.Config{Database: config.DatabaseConfig{Driver: "mysql"}}
+	daoGen := NewGobatisDAOGenerator(cfg)
+	code, err := daoGen.generateInterfaceCode(daoGen.prepareTemplateData(compositeKeyTable()))
+	if err != nil {
+		t.Fatalf("生成 DAO 失败: %v", err)
+	}
+	
+	for _, method := range []string{
+		"GetById(key model.OrderItemsKey) (*model.OrderItems, error)",
+		"DeleteById(key model.OrderItemsKey) (int64, error)",
+		"DeleteByIds(keys []model.OrderItemsKey) (int64, error)",
+		"GetExistsById(key model.OrderItemsKey) (bool, error)",
+	} {
+		if !strings.Contains(code, method) {
+			t.Errorf("期望 DAO 包含 %s", method)
+		}
+	}
+	if strings.Contains(code, "id int64") {
+		t.Error("期望复合主键的 DAO 方法不使用单列主键参数")
+	}
+}
+
+func TestStructCompositeKey(t *testing.T) {
+	structGen := NewStructGenerator(&config.Config{})
+	code, err := structGen.generateCode(structGen.prepareTemplateData(compositeKeyTable()))
+	if err != nil {
+		t.Fatalf("生成结构体失败: %v", err)
+	}
+	for _, expected := range []string{"type OrderItemsKey struct", "func (m *OrderItems) Key() OrderItemsKey"} {
+		if !strings.Contains(code, expected) {
+			t.Errorf("期望结构体代码包含 %s", expected)
+		}
+	}
+}
+
+func TestGobatisDAORelations(t *testing.T) {
+	cfg := &config.Config{Options: config.OptionsConfig{GenerateRelations: true}}
+	tables := relationTables()
+	
+	expected := map[string]string{
+		"orders":      "GetOrdersWithOrderItems(id int64) (*model.Orders, error)",
+		"order_items": "GetOrderItemsWithOrder(key model.OrderItemsKey) (*model.OrderItems, error)",
+	}
+	for _, table := range tables {
+		daoGen := NewGobatisDAOGenerator(cfg)
+		daoGen.SetTables(tables)
+		code, err := daoGen.generateInterfaceCode(daoGen.prepareTemplateData(table))
+		if err != nil {
+			t.Fatalf("生成 DAO 失败: %v", err)
+		}
+		if !strings.Contains(code, expected[table.Name]) {
+			t.Errorf("期望 %s 的 DAO 包含 %s", table.Name, expected[table.Name])
+		}
+	}
+	
+	structGen := NewStructGenerator(cfg)
+	structGen.SetTables(tables)
+	code, err := structGen.generateCode(structGen.prepareTemplateData(tables[0]))
+	if err != nil {
+		t.Fatalf("生成结构体失败: %v", err)
+	}
+	if !strings.Contains(code, "OrderItems []*OrderItems") {
+		t.Errorf("期望结构体包含一对多关联字段，实际为:\n%s", code)
+	}
+}
+
+func TestFinderKeywordColumns(t *testing.T) {
+	table := database.Table{
+		Name: "events",
+		Columns: []database.Column{
+			{Name: "id", Type: "int", GoType: "int64", IsPrimaryKey: true},
+			{Name: "type", Type: "varchar", GoType: "string"},
+			{Name: "range", Type: "varchar", GoType: "string"},
+			{Name: "len", Type: "int", GoType: "int"},
+		},
+		Indexes: []database.Index{
+			{Name: "idx_type", Columns: []string{"type"}},
+			{Name: "uk_range_len", Columns: []string{"range", "len"}, IsUnique: true},
+		},
+	}
+	cfg := &config.Config{Database: config.DatabaseConfig{Driver: "postgres"}, Options: config.OptionsConfig{NamespaceFormat: "{struct}DAO"}}
+	
+	daoGen := NewGobatisDAOGenerator(cfg)
+	code, err := daoGen.generateInterfaceCode(daoGen.prepareTemplateData(table))
+	if err != nil {
+		t.Fatalf("生成 DAO 失败: %v", err)
+	}
+	if _, err := parser.ParseFile(token.NewFileSet(), "events_dao.go", code, 0); err != nil {
+		t.Fatalf("生成的 DAO 无法解析: %v", err)
+	}
+	for _, method := range []string{"GetByType(typeParam string)", "GetByRangeAndLen(rangeParam string, lenParam int)"} {
+		if !strings.Contains(code, method) {
+			t.Errorf("期望 DAO 包含 %s", method)
+		}
+	}
+	
+	xmlGen := NewGobatisXMLGenerator(cfg)
+	xml, err := xmlGen.generateXMLCode(xmlGen.prepareTemplateData(table))
+	if err != nil {
+		t.Fatalf("生成 XML 失败: %v", err)
+	}
+	for _, where := range []string{"WHERE type = #{typeParam}", "WHERE range = #{rangeParam} AND len = #{lenParam}"} {
+		if !strings.Contains(xml, where) {
+			t.Errorf("期望 XML 使用与 DAO 一致的参数名: %s", where)
+		}
+	}
+}
+
+func TestGobatisDAOMappedPrimaryKey(t *testing.T) {
+	cfg := &config.Config{
+		Database: config.DatabaseConfig{Driver: "mysql"},
+		Types: config.TypesConfig{
+			Mappings: []config.TypeMapping{{DBType: "char(36)", GoType: "github.com/google/uuid.UUID"}},
+		},
+	}
+	tables := applyTypeMappings([]database.Table{{
+		Name: "tokens",
+		Columns: []database.Column{
+			{Name: "id", Type: "char", FullType: "char(36)", GoType: "string", IsPrimaryKey: true},
+			{Name: "name", Type: "varchar", GoType: "string"},
+		},
+	}}, cfg)
+	
+	daoGen := NewGobatisDAOGenerator(cfg)
+	code, err := daoGen.generateInterfaceCode(daoGen.prepareTemplateData(tables[0]))
+	if err != nil {
+		t.Fatalf("生成 DAO 失败: %v", err)
+	}
+	if !strings.Contains(code, "GetById(id uuid.UUID)") {
+		t.Fatalf("期望主键参数使用映射类型:\n%s", code)
+	}
+	for _, err := range undefinedPackages(t, "tokens_dao.go", code) {
+		t.Errorf("生成的 DAO 缺少导入: %v", err)
+	}
+}
+
+// undefinedPackageError 匹配未导入包名的类型检查错误，如 "undefined: uuid"
+var undefinedPackageError = regexp.MustCompile(`undefined: [A-Za-z_][A-Za-z0-9_]*$`)
+
+// undefinedPackages 对生成代码做类型检查，返回引用了未导入包的错误。
+// 依赖包以空包代替，因此只关注包名是否已导入，忽略包内成员不存在的错误
+func undefinedPackages(t *testing.T, name, code string) []error {
+	t.Helper()
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, name, code, 0)
+	if err != nil {
+		t.Fatalf("生成的代码无法解析: %v", err)
+	}
+	var undefined []error
+	conf := types.Config{
+		Importer: stubImporter{},
+		Error: func(err error) {
+			if undefinedPackageError.MatchString(err.Error()) {
+				undefined = append(undefined, err)
+			}
+		},
+	}
+	conf.Check(file.Name.Name, fset, []*ast.File{file}, nil)
+	return undefined
+}
+
+// stubImporter 为任意导入路径返回以路径末段命名的空包
+type stubImporter struct{}
+
+func (stubImporter) Import(importPath string) (*types.Package, error) {
+	pkg := types.NewPackage(importPath, path.Base(importPath))
+	pkg.MarkComplete()
+	return pkg, nil
+}
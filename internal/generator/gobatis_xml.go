@@ -14,6 +14,7 @@ import (
 // GobatisXMLGenerator gobatis XML 映射文件生成器
 type GobatisXMLGenerator struct {
 	config *config.Config
+	tables []database.Table
 }
 
 // NewGobatisXMLGenerator 创建 gobatis XML 生成器
@@ -28,11 +29,27 @@ type GobatisXMLData struct {
 	StructName      string
 	TableName       string
 	PrimaryKey      FieldData
+	Key             PrimaryKeyData
+	KeyType         string // 主键参数类型，复合主键为 Key 结构体名
 	Fields          []FieldData
+	InsertFields    []FieldData // 插入字段（不包含自增列、单列主键和生成列）
+	UpdateFields    []FieldData // 更新字段（不包含主键和生成列）
+	Finders         []FinderData
+	Relations       []RelationData
+	OrderBy         string
 	HasPrimaryKey   bool
+	UseTupleIn      bool // 复合主键批量删除是否使用 (a, b) IN 形式
+	ReturningKey    bool // 插入后通过 RETURNING 回填自增主键
+	ReadOnly        bool // 视图只生成查询语句
+	Refreshable     bool // 物化视图生成 Refresh 语句
 	GenerateExample bool
 }
 
+// SetTables 设置参与生成的表，用于解析外键关联
+func (gxg *GobatisXMLGenerator) SetTables(tables []database.Table) {
+	gxg.tables = tables
+}
+
 // Generate 生成 gobatis XML 映射文件
 func (gxg *GobatisXMLGenerator) Generate(table database.Table) error {
 	// 准备模板数据
@@ -57,7 +74,7 @@ func (gxg *GobatisXMLGenerator) Generate(table database.Table) error {
 		return fmt.Errorf("写入 XML 文件失败: %w", err)
 	}
 	
-	fmt.Printf("  生成 gobatis XML 映射文件: %s\n", xmlPath)
+	fmt.Printf("  生成 gobatis XML 映射文件: %s\n", outputPath(gxg.config, xmlPath))
 	return nil
 }
 
@@ -75,7 +92,7 @@ func (gxg *GobatisXMLGenerator) generateNamespace(structName string) string {
 
 // prepareTemplateData 准备模板数据
 func (gxg *GobatisXMLGenerator) prepareTemplateData(table database.Table) GobatisXMLData {
-	structName := toPascalCase(removeTablePrefix(table.Name, gxg.config.Tables.Prefix))
+	structName := structName(table, gxg.config)
 	
 	// 生成namespace，支持自定义格式
 	namespace := gxg.generateNamespace(structName)
@@ -84,7 +101,9 @@ func (gxg *GobatisXMLGenerator) prepareTemplateData(table database.Table) Gobati
 		Namespace:       namespace,
 		DAOName:         structName + "DAO",
 		StructName:      structName,
-		TableName:       table.Name,
+		TableName:       table.QualifiedName(),
+		ReadOnly:        table.IsView(),
+		Refreshable:     table.Kind == database.KindMaterializedView,
 		GenerateExample: gxg.config.Options.GenerateExample,
 	}
 	
@@ -95,17 +114,55 @@ func (gxg *GobatisXMLGenerator) prepareTemplateData(table database.Table) Gobati
 			Type:         col.GoType,
 			Comment:      col.Comment,
 			IsPrimaryKey: col.IsPrimaryKey,
+			IsAutoIncr:   col.IsAutoIncr,
+			Nullable:     col.Nullable,
+			IsGenerated:  col.Generated != "",
+			Length:       col.Length,
+			Precision:    col.Precision,
+			Scale:        col.Scale,
 			ColumnName:   col.Name,
 		}
 		
-		if col.IsPrimaryKey {
-			data.PrimaryKey = field
-			data.HasPrimaryKey = true
-		}
-		
 		data.Fields = append(data.Fields, field)
 	}
 	
+	// 处理主键
+	data.Key = newPrimaryKeyData(structName, data.Fields)
+	data.HasPrimaryKey = data.Key.Exists()
+	data.UseTupleIn = supportsTupleIn(gxg.config.Database.SQLDialect())
+	data.ReturningKey = returningKey(data.Key, gxg.config.Database.SQLDialect())
+	if data.Key.IsComposite() {
+		data.KeyType = data.Key.StructName
+		data.OrderBy = data.Key.Columns()
+	} else if data.HasPrimaryKey {
+		data.PrimaryKey = data.Key.Fields[0]
+		data.KeyType = data.PrimaryKey.Type
+		data.OrderBy = data.PrimaryKey.ColumnName
+	} else if len(data.Fields) > 0 {
+		data.OrderBy = data.Fields[0].ColumnName
+	}
+	
+	// 单列主键由数据库生成，复合主键的各列需要显式插入；生成列由数据库计算，不参与插入和更新
+	for _, field := range data.Fields {
+		if field.IsGenerated {
+			continue
+		}
+		if !field.IsAutoIncr && !(field.IsPrimaryKey && !data.Key.IsComposite()) {
+			data.InsertFields = append(data.InsertFields, field)
+		}
+		if !field.IsPrimaryKey {
+			data.UpdateFields = append(data.UpdateFields, field)
+		}
+	}
+	
+	// 根据外键生成关联结果映射和联表查询
+	if gxg.config.Options.GenerateRelations && data.HasPrimaryKey {
+		data.Relations = buildRelations(table, gxg.tables, gxg.config)
+	}
+	
+	// 根据索引生成查询语句
+	data.Finders = buildFinders(table, data.Fields, data.Key)
+	
 	return data
 }
 
@@ -126,35 +183,64 @@ func (gxg *GobatisXMLGenerator) generateXMLCode(data GobatisXMLData) (string, er
         {{ end }}
         {{ end }}
     </resultMap>
-
+{{ range .Relations }}
+    <!-- {{ $.StructName }} 关联 {{ .StructName }} 的结果映射（外键 {{ .ForeignKey }}） -->
+    <resultMap id="{{ .ResultMapName }}" type="{{ $.StructName }}">
+        {{- range $.Fields }}
+        {{- if .IsPrimaryKey }}
+        <id property="{{ .Name }}" column="{{ .ColumnName }}" />
+        {{- else }}
+        <result property="{{ .Name }}" column="{{ .ColumnName }}" />
+        {{- end }}
+        {{- end }}
+        {{- if .IsCollection }}
+        <collection property="{{ .Property }}" ofType="{{ .StructName }}" columnPrefix="{{ .ColumnPrefix }}">
+        {{- else }}
+        <association property="{{ .Property }}" javaType="{{ .StructName }}" columnPrefix="{{ .ColumnPrefix }}">
+        {{- end }}
+            {{- range .Fields }}
+            {{- if .IsPrimaryKey }}
+            <id property="{{ .Name }}" column="{{ .ColumnName }}" />
+            {{- else }}
+            <result property="{{ .Name }}" column="{{ .ColumnName }}" />
+            {{- end }}
+            {{- end }}
+        {{- if .IsCollection }}
+        </collection>
+        {{- else }}
+        </association>
+        {{- end }}
+    </resultMap>
+{{ end }}
     <!-- 基础字段列表 -->
     <sql id="Base_Column_List">
         {{ range $i, $field := .Fields }}{{ if $i }}, {{ end }}{{ $field.ColumnName }}{{ end }}
     </sql>
-
-    <!-- 插入字段列表（不包含主键） -->
+{{ if not .ReadOnly }}
+    <!-- 插入字段列表（不包含自增主键） -->
     <sql id="Insert_Column_List">
-        {{- $first := true }}{{ range .Fields }}{{ if not .IsPrimaryKey }}{{ if not $first }}, {{ end }}{{ .ColumnName }}{{ $first = false }}{{ end }}{{ end }}
+        {{- range $i, $field := .InsertFields }}{{ if $i }}, {{ end }}{{ $field.ColumnName }}{{ end }}
     </sql>
 
-    <!-- 插入值列表（不包含主键） -->
+    <!-- 插入值列表（不包含自增主键） -->
     <sql id="Insert_Value_List">
-        {{- $first := true }}{{ range .Fields }}{{ if not .IsPrimaryKey }}{{ if not $first }}, {{ end }}#{{"{"}}{{ .Name }}{{"}"}}{{ $first = false }}{{ end }}{{ end }}
+        {{- range $i, $field := .InsertFields }}{{ if $i }}, {{ end }}#{{"{"}}{{ $field.Name }}{{"}"}}{{ end }}
     </sql>
 
     <!-- 更新字段列表（不包含主键） -->
     <sql id="Update_Set_List">
-        {{- $first := true }}{{ range .Fields }}{{ if not .IsPrimaryKey }}{{ if not $first }}, {{ end }}{{ .ColumnName }} = #{{"{"}}{{ .Name }}{{"}"}}{{ $first = false }}{{ end }}{{ end }}
+        {{- range $i, $field := .UpdateFields }}{{ if $i }}, {{ end }}{{ $field.ColumnName }} = #{{"{"}}{{ $field.Name }}{{"}"}}{{ end }}
     </sql>
 
     <!-- Insert 方法 - 插入操作 -->
     <!-- Insert 插入单个{{ .StructName }}记录 -->
-    <insert id="Insert" parameterType="{{ .StructName }}">
+    <insert id="Insert" parameterType="{{ .StructName }}"{{ if .ReturningKey }} useGeneratedKeys="true" keyProperty="{{ .PrimaryKey.Name }}" keyColumn="{{ .PrimaryKey.ColumnName }}"{{ end }}>
         INSERT INTO {{ .TableName }} (
             <include refid="Insert_Column_List" />
         ) VALUES (
             <include refid="Insert_Value_List" />
-        )
+        ){{ if .ReturningKey }}
+        RETURNING {{ .PrimaryKey.ColumnName }}{{ end }}
     </insert>
 
     <!-- InsertBatch 批量插入{{ .StructName }}记录 -->
@@ -163,17 +249,18 @@ func (gxg *GobatisXMLGenerator) generateXMLCode(data GobatisXMLData) (string, er
             <include refid="Insert_Column_List" />
         ) VALUES
         <foreach collection="records" item="item" separator=",">
-            ({{- $first := true }}{{ range .Fields }}{{ if not .IsPrimaryKey }}{{ if not $first }}, {{ end }}#{{"{"}}item.{{ .Name }}{{"}"}}{{ $first = false }}{{ end }}{{ end }})
+            ({{- range $i, $field := .InsertFields }}{{ if $i }}, {{ end }}#{{"{"}}item.{{ $field.Name }}{{"}"}}{{ end }})
         </foreach>
     </insert>
 
     <!-- 兼容性方法 - Create -->
-    <insert id="Create" parameterType="{{ .StructName }}">
+    <insert id="Create" parameterType="{{ .StructName }}"{{ if .ReturningKey }} useGeneratedKeys="true" keyProperty="{{ .PrimaryKey.Name }}" keyColumn="{{ .PrimaryKey.ColumnName }}"{{ end }}>
         INSERT INTO {{ .TableName }} (
             <include refid="Insert_Column_List" />
         ) VALUES (
             <include refid="Insert_Value_List" />
-        )
+        ){{ if .ReturningKey }}
+        RETURNING {{ .PrimaryKey.ColumnName }}{{ end }}
     </insert>
 
     <!-- 兼容性方法 - CreateBatch -->
@@ -182,27 +269,48 @@ func (gxg *GobatisXMLGenerator) generateXMLCode(data GobatisXMLData) (string, er
             <include refid="Insert_Column_List" />
         ) VALUES
         <foreach collection="Items" item="item" separator=",">
-            ({{- $first := true }}{{ range .Fields }}{{ if not .IsPrimaryKey }}{{ if not $first }}, {{ end }}#{{"{"}}item.{{ .Name }}{{"}"}}{{ $first = false }}{{ end }}{{ end }})
+            ({{- range $i, $field := .InsertFields }}{{ if $i }}, {{ end }}#{{"{"}}item.{{ $field.Name }}{{"}"}}{{ end }})
         </foreach>
     </insert>
-
+{{ end }}
     <!-- Select 方法 - 查询操作 -->
 {{ if .HasPrimaryKey }}
     <!-- SelectById 根据ID查询{{ .StructName }}记录 -->
-    <select id="SelectById" parameterType="{{ .PrimaryKey.Type }}" resultMap="{{ .StructName }}ResultMap">
+    <select id="SelectById" parameterType="{{ .KeyType }}" resultMap="{{ .StructName }}ResultMap">
         SELECT 
             <include refid="Base_Column_List" />
         FROM {{ .TableName }}
-        WHERE {{ .PrimaryKey.ColumnName }} = #{{"{"}}{{ .PrimaryKey.Name }}{{"}"}}
+        WHERE {{ .Key.Where "" }}
     </select>
 {{ end }}
 
+{{ range .Finders }}
+    <!-- {{ .Name }} 根据{{ if .IsUnique }}唯一{{ end }}索引 {{ .IndexName }} 查询{{ $.StructName }}记录 -->
+    <select id="{{ .Name }}" parameterType="{{ .ParameterType }}" resultMap="{{ $.StructName }}ResultMap">
+        SELECT 
+            <include refid="Base_Column_List" />
+        FROM {{ $.TableName }}
+        WHERE {{ .Where }}{{ if not .IsUnique }}
+        ORDER BY {{ $.OrderBy }}{{ end }}
+    </select>
+{{ end }}
+{{- range $relation := .Relations }}
+    <!-- {{ .MethodName }} 根据主键查询{{ $.StructName }}记录并加载关联的{{ .StructName }} -->
+    <select id="{{ .MethodName }}" parameterType="{{ $.KeyType }}" resultMap="{{ .ResultMapName }}">
+        SELECT 
+            {{ range $i, $field := $.Fields }}{{ if $i }}, {{ end }}t.{{ $field.ColumnName }}{{ end }},
+            {{ range $i, $field := .Fields }}{{ if $i }}, {{ end }}r.{{ $field.ColumnName }} AS {{ $relation.ColumnPrefix }}{{ $field.ColumnName }}{{ end }}
+        FROM {{ $.TableName }} t
+        LEFT JOIN {{ .TableName }} r ON {{ .JoinOn }}
+        WHERE {{ $.Key.AliasWhere "t" "" }}
+    </select>
+{{ end }}
     <!-- SelectAll 查询所有{{ .StructName }}记录 -->
     <select id="SelectAll" resultMap="{{ .StructName }}ResultMap">
         SELECT 
             <include refid="Base_Column_List" />
         FROM {{ .TableName }}
-        ORDER BY {{ if .HasPrimaryKey }}{{ .PrimaryKey.ColumnName }}{{ else }}{{ (index .Fields 0).ColumnName }}{{ end }}
+        ORDER BY {{ .OrderBy }}
     </select>
 
     <!-- SelectByPage 分页查询{{ .StructName }}记录 -->
@@ -210,7 +318,7 @@ func (gxg *GobatisXMLGenerator) generateXMLCode(data GobatisXMLData) (string, er
         SELECT 
             <include refid="Base_Column_List" />
         FROM {{ .TableName }}
-        ORDER BY {{ if .HasPrimaryKey }}{{ .PrimaryKey.ColumnName }}{{ else }}{{ (index .Fields 0).ColumnName }}{{ end }}
+        ORDER BY {{ .OrderBy }}
         LIMIT #{{"{"}}limit{{"}"}} OFFSET #{{"{"}}offset{{"}"}}
     </select>
 
@@ -224,41 +332,38 @@ func (gxg *GobatisXMLGenerator) generateXMLCode(data GobatisXMLData) (string, er
                 ${condition}
             </if>
         </where>
-        ORDER BY {{ if .HasPrimaryKey }}{{ .PrimaryKey.ColumnName }}{{ else }}{{ (index .Fields 0).ColumnName }}{{ end }}
+        ORDER BY {{ .OrderBy }}
     </select>
-
+{{ if not .ReadOnly }}
     <!-- Update 方法 - 更新操作 -->
 {{ if .HasPrimaryKey }}
     <!-- UpdateById 根据ID更新{{ .StructName }}记录 -->
     <update id="UpdateById" parameterType="{{ .StructName }}">
         UPDATE {{ .TableName }}
         SET <include refid="Update_Set_List" />
-        WHERE {{ .PrimaryKey.ColumnName }} = #{{"{"}}{{ .PrimaryKey.Name }}{{"}"}}
+        WHERE {{ .Key.Where "" }}
     </update>
 {{ end }}
 
     <!-- Delete 方法 - 删除操作 -->
 {{ if .HasPrimaryKey }}
     <!-- DeleteById 根据ID删除{{ .StructName }}记录 -->
-    <delete id="DeleteById" parameterType="{{ .PrimaryKey.Type }}">
+    <delete id="DeleteById" parameterType="{{ .KeyType }}">
         DELETE FROM {{ .TableName }}
-        WHERE {{ .PrimaryKey.ColumnName }} = #{{"{"}}{{ .PrimaryKey.Name }}{{"}"}}
+        WHERE {{ .Key.Where "" }}
     </delete>
 
     <!-- DeleteByIds 根据ID列表批量删除{{ .StructName }}记录 -->
     <delete id="DeleteByIds" parameterType="map">
         DELETE FROM {{ .TableName }}
-        WHERE {{ .PrimaryKey.ColumnName }} IN
-        <foreach collection="ids" item="id" open="(" separator="," close=")">
-            #{{"{"}}id{{"}"}}
-        </foreach>
+{{ template "deleteByKeys" (deleteByKeysArgs $ "ids") }}
     </delete>
-
+{{ end }}{{ end }}{{ if .HasPrimaryKey }}
     <!-- ExistsById 检查指定ID的{{ .StructName }}记录是否存在 -->
-    <select id="ExistsById" parameterType="{{ .PrimaryKey.Type }}" resultType="bool">
+    <select id="ExistsById" parameterType="{{ .KeyType }}" resultType="bool">
         SELECT COUNT(1) > 0
         FROM {{ .TableName }}
-        WHERE {{ .PrimaryKey.ColumnName }} = #{{"{"}}{{ .PrimaryKey.Name }}{{"}"}}
+        WHERE {{ .Key.Where "" }}
     </select>
 {{ end }}
 
@@ -283,40 +388,38 @@ func (gxg *GobatisXMLGenerator) generateXMLCode(data GobatisXMLData) (string, er
     <!-- 兼容性方法 -->
 {{ if .HasPrimaryKey }}
     <!-- 兼容性方法 - GetByID -->
-    <select id="GetByID" parameterType="{{ .PrimaryKey.Type }}" resultMap="{{ .StructName }}ResultMap">
+    <select id="GetByID" parameterType="{{ .KeyType }}" resultMap="{{ .StructName }}ResultMap">
         SELECT 
             <include refid="Base_Column_List" />
         FROM {{ .TableName }}
-        WHERE {{ .PrimaryKey.ColumnName }} = #{{"{"}}{{ .PrimaryKey.Name }}{{"}"}}
+        WHERE {{ .Key.Where "" }}
     </select>
-
+{{ if not .ReadOnly }}
     <!-- 兼容性方法 - UpdateByID -->
     <update id="UpdateByID" parameterType="{{ .StructName }}">
         UPDATE {{ .TableName }}
         SET <include refid="Update_Set_List" />
-        WHERE {{ .PrimaryKey.ColumnName }} = #{{"{"}}{{ .PrimaryKey.Name }}{{"}"}}
+        WHERE {{ .Key.Where "" }}
     </update>
 
     <!-- 兼容性方法 - DeleteByID -->
-    <delete id="DeleteByID" parameterType="{{ .PrimaryKey.Type }}">
+    <delete id="DeleteByID" parameterType="{{ .KeyType }}">
         DELETE FROM {{ .TableName }}
-        WHERE {{ .PrimaryKey.ColumnName }} = #{{"{"}}{{ .PrimaryKey.Name }}{{"}"}}
+        WHERE {{ .Key.Where "" }}
     </delete>
 
     <!-- 兼容性方法 - DeleteByIDs -->
     <delete id="DeleteByIDs" parameterType="map">
         DELETE FROM {{ .TableName }}
-        WHERE {{ .PrimaryKey.ColumnName }} IN
-        <foreach collection="IDs" item="id" open="(" separator="," close=")">
-            #{{"{"}}id{{"}"}}
-        </foreach>
+{{ template "deleteByKeys" (deleteByKeysArgs $ "IDs") }}
     </delete>
+{{- end }}
 
     <!-- 兼容性方法 - Exists -->
-    <select id="Exists" parameterType="{{ .PrimaryKey.Type }}" resultType="int64">
+    <select id="Exists" parameterType="{{ .KeyType }}" resultType="int64">
         SELECT COUNT(1)
         FROM {{ .TableName }}
-        WHERE {{ .PrimaryKey.ColumnName }} = #{{"{"}}{{ .PrimaryKey.Name }}{{"}"}}
+        WHERE {{ .Key.Where "" }}
     </select>
 {{ end }}
 
@@ -325,7 +428,7 @@ func (gxg *GobatisXMLGenerator) generateXMLCode(data GobatisXMLData) (string, er
         SELECT 
             <include refid="Base_Column_List" />
         FROM {{ .TableName }}
-        ORDER BY {{ if .HasPrimaryKey }}{{ .PrimaryKey.ColumnName }}{{ else }}{{ (index .Fields 0).ColumnName }}{{ end }}
+        ORDER BY {{ .OrderBy }}
     </select>
 
     <!-- 兼容性方法 - GetByPage -->
@@ -333,7 +436,7 @@ func (gxg *GobatisXMLGenerator) generateXMLCode(data GobatisXMLData) (string, er
         SELECT 
             <include refid="Base_Column_List" />
         FROM {{ .TableName }}
-        ORDER BY {{ if .HasPrimaryKey }}{{ .PrimaryKey.ColumnName }}{{ else }}{{ (index .Fields 0).ColumnName }}{{ end }}
+        ORDER BY {{ .OrderBy }}
         LIMIT #{{"{"}}limit{{"}"}} OFFSET #{{"{"}}offset{{"}"}}
     </select>
 
@@ -344,12 +447,12 @@ func (gxg *GobatisXMLGenerator) generateXMLCode(data GobatisXMLData) (string, er
         FROM {{ .TableName }}
         <where>
             {{ range .Fields }}
-            <if test="{{ .Name }} != null{{ if eq .Type "string" }} and {{ .Name }} != ''{{ end }}">
+            <if test="{{ .NotNullTest "" }}{{ if and (eq .Type "string") (not .Nullable) }} and {{ .Name }} != ''{{ end }}">
                 AND {{ .ColumnName }} = #{{"{"}}{{ .Name }}{{"}"}}
             </if>
             {{ end }}
         </where>
-        ORDER BY {{ if .HasPrimaryKey }}{{ .PrimaryKey.ColumnName }}{{ else }}{{ (index .Fields 0).ColumnName }}{{ end }}
+        ORDER BY {{ .OrderBy }}
     </select>
 
 {{ if .GenerateExample }}
@@ -421,16 +524,16 @@ func (gxg *GobatisXMLGenerator) generateXMLCode(data GobatisXMLData) (string, er
             </if>
         </where>
     </select>
-
+{{ if not .ReadOnly }}
     <!-- UpdateByExample 根据 Example 更新{{ .StructName }}记录 -->
     <update id="UpdateByExample" parameterType="map">
         UPDATE {{ .TableName }}
         <set>
-            {{ range .Fields }}{{ if not .IsPrimaryKey }}
-            <if test="record.{{ .Name }} != null">
-                {{ .ColumnName }} = #{{"{"}}record.{{ .Name }}{{"}"}}{{ if not (eq . (index $.Fields (sub (len $.Fields) 1))) }},{{ end }}
+            {{ range $i, $field := .UpdateFields }}
+            <if test="{{ $field.NotNullTest "record." }}">
+                {{ $field.ColumnName }} = #{{"{"}}record.{{ $field.Name }}{{"}"}}{{ if ne $i (sub (len $.UpdateFields) 1) }},{{ end }}
             </if>
-            {{ end }}{{ end }}
+            {{ end }}
         </set>
         <where>
             <if test="example.criteria != null and example.criteria.size() > 0">
@@ -485,15 +588,36 @@ func (gxg *GobatisXMLGenerator) generateXMLCode(data GobatisXMLData) (string, er
         </where>
     </delete>
 {{ end }}
-
+{{ end }}
+{{- if .Refreshable }}
+    <!-- Refresh 刷新物化视图{{ .StructName }} -->
+    <update id="Refresh">
+        REFRESH MATERIALIZED VIEW {{ .TableName }}
+    </update>
+{{ end }}
 </mapper>
-`
+{{ define "deleteByKeys" }}{{ $data := .Data }}{{ if not $data.Key.IsComposite }}        WHERE {{ $data.PrimaryKey.ColumnName }} IN
+        <foreach collection="{{ .Collection }}" item="id" open="(" separator="," close=")">
+            #{{"{"}}id{{"}"}}
+        </foreach>{{ else if $data.UseTupleIn }}        WHERE ({{ $data.Key.Columns }}) IN
+        <foreach collection="{{ .Collection }}" item="key" open="(" separator="," close=")">
+            ({{ range $i, $field := $data.Key.Fields }}{{ if $i }}, {{ end }}#{{"{"}}key.{{ $field.Name }}{{"}"}}{{ end }})
+        </foreach>{{ else }}        WHERE
+        <foreach collection="{{ .Collection }}" item="key" open="(" separator=" OR " close=")">
+            ({{ $data.Key.Where "key." }})
+        </foreach>{{ end }}{{ end }}`
 	
 	// 添加模板函数
 	funcMap := template.FuncMap{
 		"sub": func(a, b int) int {
 			return a - b
 		},
+		"deleteByKeysArgs": func(data GobatisXMLData, collection string) map[string]interface{} {
+			return map[string]interface{}{
+				"Data":       data,
+				"Collection": collection,
+			}
+		},
 	}
 	
 	t, err := template.New("gobatis_xml").Funcs(funcMap).Parse(tmpl)
@@ -0,0 +1,214 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"go-mapper-gen/internal/config"
+	"go-mapper-gen/internal/database"
+)
+
+func TestGobatisXMLGeneratedColumns(t *testing.T) {
+	table := database.Table{Name: "items", Columns: []database.Column{
+		{Name: "id", Type: "int", GoType: "int", IsPrimaryKey: true, IsAutoIncr: true},
+		{Name: "name", Type: "varchar", GoType: "string", Length: 50},
+		{Name: "price", Type: "decimal", GoType: "float64", Precision: 10, Scale: 2},
+		{Name: "total", Type: "decimal", GoType: "*float64", Nullable: true, Generated: "STORED", GeneratedExpr: "price * 2"},
+	}}
+	
+	data := NewGobatisXMLGenerator(&config.Config{}).prepareTemplateData(table)
+	if names := fieldNames(data.InsertFields); names != "Name,Price" {
+		t.Errorf("期望插入字段不包含生成列，实际为 %s", names)
+	}
+	if names := fieldNames(data.UpdateFields); names != "Name,Price" {
+		t.Errorf("期望更新字段不包含生成列，实际为 %s", names)
+	}
+	if len(data.Fields) != 4 || !data.Fields[3].IsGenerated {
+		t.Errorf("期望查询字段包含生成列: %+v", data.Fields)
+	}
+	if data.Fields[1].Length != 50 || data.Fields[2].Precision != 10 || data.Fields[2].Scale != 2 {
+		t.Errorf("期望字段包含长度和精度: %+v", data.Fields)
+	}
+}
+
+func TestGobatisReadOnlyViews(t *testing.T) {
+	cfg := &config.Config{Options: config.OptionsConfig{GenerateExample: true}}
+	view := database.Table{Name: "order_totals", Kind: database.KindMaterializedView, Columns: []database.Column{
+		{Name: "user_id", Type: "int", GoType: "int"},
+		{Name: "total", Type: "numeric", GoType: "float64"},
+	}}
+	
+	xmlGen := NewGobatisXMLGenerator(cfg)
+	xml, err := xmlGen.generateXMLCode(xmlGen.prepareTemplateData(view))
+	if err != nil {
+		t.Fatalf("生成 XML 失败: %v", err)
+	}
+	for _, statement := range []string{"<insert", "<delete", "UPDATE order_totals", "Insert_Column_List"} {
+		if strings.Contains(xml, statement) {
+			t.Errorf("期望视图的 XML 不包含 %s", statement)
+		}
+	}
+	for _, statement := range []string{`<select id="SelectByExample"`, `<select id="Count"`, "REFRESH MATERIALIZED VIEW order_totals"} {
+		if !strings.Contains(xml, statement) {
+			t.Errorf("期望视图的 XML 包含 %s", statement)
+		}
+	}
+	
+	daoGen := NewGobatisDAOGenerator(cfg)
+	code, err := daoGen.generateInterfaceCode(daoGen.prepareTemplateData(view))
+	if err != nil {
+		t.Fatalf("生成 DAO 失败: %v", err)
+	}
+	for _, method := range []string{"Insert(", "UpdateByCondition(", "DeleteByExample("} {
+		if strings.Contains(code, method) {
+			t.Errorf("期望视图的 DAO 不包含 %s", method)
+		}
+	}
+	for _, method := range []string{"GetByExample(", "CountByExample(", "Refresh()"} {
+		if !strings.Contains(code, method) {
+			t.Errorf("期望视图的 DAO 包含 %s", method)
+		}
+	}
+	
+	view.Kind = database.KindView
+	code, _ = daoGen.generateInterfaceCode(daoGen.prepareTemplateData(view))
+	if strings.Contains(code, "Refresh()") {
+		t.Error("期望普通视图不生成 Refresh 方法")
+	}
+}
+
+// compositeKeyTable 复合主键的测试表
+func compositeKeyTable() database.Table {
+	return database.Table{Name: "order_items", Columns: []database.Column{
+		{Name: "order_id", Type: "int", GoType: "int64", IsPrimaryKey: true},
+		{Name: "line_no", Type: "int", GoType: "int", IsPrimaryKey: true},
+		{Name: "qty", Type: "int", GoType: "int"},
+	}}
+}
+
+func TestGobatisXMLCompositeKey(t *testing.T) {
+	table := compositeKeyTable()
+	
+	key := newPrimaryKeyData("OrderItems", NewGobatisXMLGenerator(&config.Config{}).prepareTemplateData(table).Fields)
+	if !key.IsComposite() || key.Columns() != "order_id, line_no" {
+		t.Fatalf("期望复合主键为 order_id, line_no，实际为 %s", key.Columns())
+	}
+	if where := key.Where("key."); where != "order_id = #{key.OrderId} AND line_no = #{key.LineNo}" {
+		t.Errorf("主键条件不正确: %s", where)
+	}
+	if where := key.AliasWhere("t", ""); where != "t.order_id = #{OrderId} AND t.line_no = #{LineNo}" {
+		t.Errorf("带别名的主键条件不正确: %s", where)
+	}
+	if placeholders := key.Placeholders(); placeholders != "order_id = ? AND line_no = ?" {
+		t.Errorf("占位符条件不正确: %s", placeholders)
+	}
+	
+	tests := []struct {
+		driver   string
+		dialect  string
+		expected []string
+	}{
+		{"mysql", "", []string{
+			`<select id="SelectById" parameterType="OrderItemsKey" resultMap="OrderItemsResultMap">`,
+			"WHERE order_id = #{OrderId} AND line_no = #{LineNo}",
+			"WHERE (order_id, line_no) IN",
+			"(#{key.OrderId}, #{key.LineNo})",
+			`<id property="OrderId" column="order_id" />`,
+			`<id property="LineNo" column="line_no" />`,
+		}},
+		{"sqlite", "", []string{
+			`<foreach collection="ids" item="key" open="(" separator=" OR " close=")">`,
+			"(order_id = #{key.OrderId} AND line_no = #{key.LineNo})",
+		}},
+		// 按表结构来源的方言判断，而不是驱动名
+		{"ddl", "postgres", []string{"WHERE (order_id, line_no) IN"}},
+	}
+	for _, tt := range tests {
+		cfg := &config.Config{Database: config.DatabaseConfig{Driver: tt.driver, Dialect: tt.dialect}, Options: config.OptionsConfig{NamespaceFormat: "{struct}DAO"}}
+		xmlGen := NewGobatisXMLGenerator(cfg)
+		xml, err := xmlGen.generateXMLCode(xmlGen.prepareTemplateData(table))
+		if err != nil {
+			t.Fatalf("生成 XML 失败: %v", err)
+		}
+		for _, expected := range tt.expected {
+			if !strings.Contains(xml, expected) {
+				t.Errorf("期望 %s 的 XML 包含 %s", tt.driver, expected)
+			}
+		}
+	}
+}
+
+// relationTables 通过外键关联的测试表：order_items.order_id 引用 orders.id
+func relationTables() []database.Table {
+	orders := database.Table{Name: "orders", Columns: []database.Column{
+		{Name: "id", Type: "int", GoType: "int64", IsPrimaryKey: true, IsAutoIncr: true},
+		{Name: "user_id", Type: "int", GoType: "int64"},
+	}}
+	items := compositeKeyTable()
+	items.ForeignKeys = []database.ForeignKey{
+		{Name: "fk_items_order", Columns: []string{"order_id"}, RefTable: "orders", RefColumns: []string{"id"}},
+	}
+	return []database.Table{orders, items}
+}
+
+func TestGobatisXMLRelations(t *testing.T) {
+	cfg := &config.Config{
+		Database: config.DatabaseConfig{Driver: "mysql"},
+		Options:  config.OptionsConfig{GenerateRelations: true, NamespaceFormat: "{struct}DAO"},
+	}
+	tables := relationTables()
+	
+	tests := []struct {
+		table    database.Table
+		expected []string
+	}{
+		{tables[0], []string{
+			`<resultMap id="OrdersWithOrderItemsResultMap" type="Orders">`,
+			`<collection property="OrderItems" ofType="OrderItems" columnPrefix="order_items__">`,
+			`<select id="GetOrdersWithOrderItems" parameterType="int64" resultMap="OrdersWithOrderItemsResultMap">`,
+			"r.order_id AS order_items__order_id, r.line_no AS order_items__line_no, r.qty AS order_items__qty",
+			"LEFT JOIN order_items r ON r.order_id = t.id",
+		}},
+		{tables[1], []string{
+			`<resultMap id="OrderItemsWithOrderResultMap" type="OrderItems">`,
+			`<association property="Order" javaType="Orders" columnPrefix="order__">`,
+			`<select id="GetOrderItemsWithOrder" parameterType="OrderItemsKey" resultMap="OrderItemsWithOrderResultMap">`,
+			"LEFT JOIN orders r ON r.id = t.order_id",
+			"WHERE t.order_id = #{OrderId} AND t.line_no = #{LineNo}",
+		}},
+	}
+	for _, tt := range tests {
+		xmlGen := NewGobatisXMLGenerator(cfg)
+		xmlGen.SetTables(tables)
+		xml, err := xmlGen.generateXMLCode(xmlGen.prepareTemplateData(tt.table))
+		if err != nil {
+			t.Fatalf("生成 XML 失败: %v", err)
+		}
+		for _, expected := range tt.expected {
+			if !strings.Contains(xml, expected) {
+				t.Errorf("期望 %s 的 XML 包含 %s", tt.table.Name, expected)
+			}
+		}
+	}
+	
+	// 关闭关联生成时不生成结果映射和联表查询
+	cfg.Options.GenerateRelations = false
+	xmlGen := NewGobatisXMLGenerator(cfg)
+	xmlGen.SetTables(tables)
+	xml, _ := xmlGen.generateXMLCode(xmlGen.prepareTemplateData(tables[0]))
+	if strings.Contains(xml, "<collection") || strings.Contains(xml, "GetOrdersWithOrderItems") {
+		t.Error("期望关闭 generate_relations 后不生成关联映射")
+	}
+}
+
+// fieldNames 返回逗号分隔的字段名
+func fieldNames(fields []FieldData) string {
+	var names string
+	for i, field := range fields {
+		if i > 0 {
+			names += ","
+		}
+		names += field.Name
+	}
+	return names
+}
@@ -0,0 +1,366 @@
+package generator
+
+import (
+	"go-mapper-gen/internal/database"
+)
+
+// helperType 生成到模型包中的数据库专用类型，用于标准库没有合适类型的列
+type helperType struct {
+	imports []string // 代码需要的导入
+	deps    []string // 依赖的其他辅助代码
+	code    string
+}
+
+// helperTypeNames 辅助代码的生成顺序
+var helperTypeNames = []string{"Bit", "Inet", "Cidr", "MacAddr", "Interval", "TimeRange", "textValue"}
+
+// helperTypes 辅助代码定义，键为类型名或函数名
+var helperTypes = map[string]helperType{
+	"Bit": {
+		imports: []string{"database/sql/driver", "fmt"},
+		code: `// Bit 对应 MySQL BIT(n) 列，数据库以大端字节序返回
+type Bit uint64
+
+// Scan 实现 sql.Scanner 接口
+func (b *Bit) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*b = 0
+	case []byte:
+		var n uint64
+		for _, c := range v {
+			n = n<<8 | uint64(c)
+		}
+		*b = Bit(n)
+	case int64:
+		*b = Bit(v)
+	default:
+		return fmt.Errorf("无法将 %T 转换为 Bit", src)
+	}
+	return nil
+}
+
+// Value 实现 driver.Valuer 接口
+func (b Bit) Value() (driver.Value, error) {
+	return int64(b), nil
+}`,
+	},
+	"Inet": {
+		imports: []string{"database/sql/driver", "fmt", "net/netip", "strings"},
+		deps:    []string{"textValue"},
+		code: `// Inet 对应 PostgreSQL inet 列，保存主机地址和可选的网络前缀长度
+type Inet struct {
+	netip.Prefix
+}
+
+// Scan 实现 sql.Scanner 接口
+func (i *Inet) Scan(src interface{}) error {
+	s, ok, err := textValue(src, "Inet")
+	if err != nil || !ok {
+		*i = Inet{}
+		return err
+	}
+	if !strings.Contains(s, "/") {
+		addr, err := netip.ParseAddr(s)
+		if err != nil {
+			return fmt.Errorf("解析 inet 值 %q 失败: %w", s, err)
+		}
+		i.Prefix = netip.PrefixFrom(addr, addr.BitLen())
+		return nil
+	}
+	prefix, err := netip.ParsePrefix(s)
+	if err != nil {
+		return fmt.Errorf("解析 inet 值 %q 失败: %w", s, err)
+	}
+	i.Prefix = prefix
+	return nil
+}
+
+// String 返回 inet 的文本形式，单个主机地址省略前缀长度
+func (i Inet) String() string {
+	if i.Bits() == i.Addr().BitLen() {
+		return i.Addr().String()
+	}
+	return i.Prefix.String()
+}
+
+// Value 实现 driver.Valuer 接口
+func (i Inet) Value() (driver.Value, error) {
+	if !i.IsValid() {
+		return nil, nil
+	}
+	return i.String(), nil
+}`,
+	},
+	"Cidr": {
+		imports: []string{"database/sql/driver", "fmt", "net/netip"},
+		deps:    []string{"textValue"},
+		code: `// Cidr 对应 PostgreSQL cidr 列
+type Cidr struct {
+	netip.Prefix
+}
+
+// Scan 实现 sql.Scanner 接口
+func (c *Cidr) Scan(src interface{}) error {
+	s, ok, err := textValue(src, "Cidr")
+	if err != nil || !ok {
+		*c = Cidr{}
+		return err
+	}
+	prefix, err := netip.ParsePrefix(s)
+	if err != nil {
+		return fmt.Errorf("解析 cidr 值 %q 失败: %w", s, err)
+	}
+	c.Prefix = prefix
+	return nil
+}
+
+// Value 实现 driver.Valuer 接口
+func (c Cidr) Value() (driver.Value, error) {
+	if !c.IsValid() {
+		return nil, nil
+	}
+	return c.Prefix.String(), nil
+}`,
+	},
+	"MacAddr": {
+		imports: []string{"database/sql/driver", "fmt", "net"},
+		deps:    []string{"textValue"},
+		code: `// MacAddr 对应 PostgreSQL macaddr 和 macaddr8 列
+type MacAddr net.HardwareAddr
+
+// Scan 实现 sql.Scanner 接口
+func (m *MacAddr) Scan(src interface{}) error {
+	s, ok, err := textValue(src, "MacAddr")
+	if err != nil || !ok {
+		*m = nil
+		return err
+	}
+	addr, err := net.ParseMAC(s)
+	if err != nil {
+		return fmt.Errorf("解析 macaddr 值 %q 失败: %w", s, err)
+	}
+	*m = MacAddr(addr)
+	return nil
+}
+
+// String 返回以冒号分隔的 MAC 地址
+func (m MacAddr) String() string {
+	return net.HardwareAddr(m).String()
+}
+
+// Value 实现 driver.Valuer 接口
+func (m MacAddr) Value() (driver.Value, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return m.String(), nil
+}`,
+	},
+	"Interval": {
+		imports: []string{"database/sql/driver", "fmt", "strconv", "strings", "time"},
+		deps:    []string{"textValue"},
+		code: `// Interval 对应 PostgreSQL interval 列，与数据库一致地分别保存月、天和微秒
+type Interval struct {
+	Months       int32
+	Days         int32
+	Microseconds int64
+}
+
+// Duration 按每月 30 天、每天 24 小时换算为 time.Duration
+func (iv Interval) Duration() time.Duration {
+	days := int64(iv.Months)*30 + int64(iv.Days)
+	return time.Duration(days)*24*time.Hour + time.Duration(iv.Microseconds)*time.Microsecond
+}
+
+// Scan 实现 sql.Scanner 接口，支持默认的 postgres 输出格式，如 1 year 2 mons 3 days 04:05:06
+func (iv *Interval) Scan(src interface{}) error {
+	s, ok, err := textValue(src, "Interval")
+	if err != nil || !ok {
+		*iv = Interval{}
+		return err
+	}
+
+	var result Interval
+	fields := strings.Fields(s)
+	for i := 0; i < len(fields); i++ {
+		if strings.Contains(fields[i], ":") {
+			clock := strings.TrimLeft(fields[i], "+-")
+			parts := strings.Split(clock, ":")
+			if len(parts) != 3 {
+				return fmt.Errorf("解析 interval 值 %q 失败", s)
+			}
+			d, err := time.ParseDuration(parts[0] + "h" + parts[1] + "m" + parts[2] + "s")
+			if err != nil {
+				return fmt.Errorf("解析 interval 值 %q 失败: %w", s, err)
+			}
+			if strings.HasPrefix(fields[i], "-") {
+				d = -d
+			}
+			result.Microseconds += d.Microseconds()
+			continue
+		}
+
+		if i+1 >= len(fields) {
+			return fmt.Errorf("解析 interval 值 %q 失败: 缺少单位", s)
+		}
+		n, err := strconv.ParseInt(fields[i], 10, 32)
+		if err != nil {
+			return fmt.Errorf("解析 interval 值 %q 失败: %w", s, err)
+		}
+		i++
+		switch strings.TrimSuffix(fields[i], "s") {
+		case "year":
+			result.Months += int32(n) * 12
+		case "mon":
+			result.Months += int32(n)
+		case "day":
+			result.Days += int32(n)
+		default:
+			return fmt.Errorf("解析 interval 值 %q 失败: 不支持的单位 %s", s, fields[i])
+		}
+	}
+	*iv = result
+	return nil
+}
+
+// Value 实现 driver.Valuer 接口
+func (iv Interval) Value() (driver.Value, error) {
+	return fmt.Sprintf("%d months %d days %d microseconds", iv.Months, iv.Days, iv.Microseconds), nil
+}`,
+	},
+	"TimeRange": {
+		imports: []string{"database/sql/driver", "fmt", "strings", "time"},
+		deps:    []string{"textValue"},
+		code: `// TimeRange 对应 PostgreSQL tsrange、tstzrange 和 daterange 列，Lower/Upper 为 nil 表示无界
+type TimeRange struct {
+	Lower          *time.Time
+	Upper          *time.Time
+	LowerInclusive bool
+	UpperInclusive bool
+	Empty          bool // 空范围
+}
+
+// rangeTimeLayouts 范围边界的时间格式，依次尝试
+var rangeTimeLayouts = []string{
+	"2006-01-02 15:04:05.999999999-07:00",
+	"2006-01-02 15:04:05.999999999-07",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02",
+}
+
+// Scan 实现 sql.Scanner 接口
+func (r *TimeRange) Scan(src interface{}) error {
+	s, ok, err := textValue(src, "TimeRange")
+	if err != nil || !ok {
+		*r = TimeRange{}
+		return err
+	}
+	if s == "empty" {
+		*r = TimeRange{Empty: true}
+		return nil
+	}
+
+	bounds := strings.SplitN(strings.Trim(s, "[]()"), ",", 2)
+	if len(s) < 3 || len(bounds) != 2 {
+		return fmt.Errorf("解析范围值 %q 失败", s)
+	}
+	result := TimeRange{LowerInclusive: s[0] == '[', UpperInclusive: s[len(s)-1] == ']'}
+	for i, bound := range bounds {
+		bound = strings.Trim(bound, "\"")
+		if bound == "" || bound == "infinity" || bound == "-infinity" {
+			continue
+		}
+		var t time.Time
+		for _, layout := range rangeTimeLayouts {
+			if t, err = time.Parse(layout, bound); err == nil {
+				break
+			}
+		}
+		if err != nil {
+			return fmt.Errorf("解析范围值 %q 失败: %w", s, err)
+		}
+		if i == 0 {
+			result.Lower = &t
+		} else {
+			result.Upper = &t
+		}
+	}
+	*r = result
+	return nil
+}
+
+// Value 实现 driver.Valuer 接口
+func (r TimeRange) Value() (driver.Value, error) {
+	if r.Empty {
+		return "empty", nil
+	}
+
+	var b strings.Builder
+	if r.LowerInclusive {
+		b.WriteByte('[')
+	} else {
+		b.WriteByte('(')
+	}
+	if r.Lower != nil {
+		fmt.Fprintf(&b, "%q", r.Lower.Format(rangeTimeLayouts[0]))
+	}
+	b.WriteByte(',')
+	if r.Upper != nil {
+		fmt.Fprintf(&b, "%q", r.Upper.Format(rangeTimeLayouts[0]))
+	}
+	if r.UpperInclusive {
+		b.WriteByte(']')
+	} else {
+		b.WriteByte(')')
+	}
+	return b.String(), nil
+}`,
+	},
+	"textValue": {
+		imports: []string{"fmt"},
+		code: `// textValue 将数据库返回的文本值转换为字符串，NULL 时 ok 为 false
+func textValue(src interface{}, typeName string) (s string, ok bool, err error) {
+	switch v := src.(type) {
+	case nil:
+		return "", false, nil
+	case string:
+		return v, true, nil
+	case []byte:
+		return string(v), true, nil
+	default:
+		return "", false, fmt.Errorf("无法将 %T 转换为 %s", src, typeName)
+	}
+}`,
+	},
+}
+
+// usedHelperTypes 返回表结构中用到的辅助代码名称，包含依赖，按生成顺序排列
+func usedHelperTypes(tables []database.Table) []string {
+	used := make(map[string]bool)
+	var mark func(name string)
+	mark = func(name string) {
+		if used[name] {
+			return
+		}
+		used[name] = true
+		for _, dep := range helperTypes[name].deps {
+			mark(dep)
+		}
+	}
+	for _, table := range tables {
+		for _, col := range table.Columns {
+			if _, ok := helperTypes[nonNullGoType(col.GoType)]; ok {
+				mark(nonNullGoType(col.GoType))
+			}
+		}
+	}
+	
+	var names []string
+	for _, name := range helperTypeNames {
+		if used[name] {
+			names = append(names, name)
+		}
+	}
+	return names
+}
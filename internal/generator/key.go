@@ -0,0 +1,83 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+)
+
+// PrimaryKeyData 主键模板数据，同时支持单列主键和复合主键
+type PrimaryKeyData struct {
+	StructName string      // 复合主键结构体名，如 OrderItemsKey
+	Fields     []FieldData // 主键字段，按列顺序排列
+}
+
+// newPrimaryKeyData 从字段列表中提取主键信息
+func newPrimaryKeyData(structName string, fields []FieldData) PrimaryKeyData {
+	key := PrimaryKeyData{StructName: structName + "Key"}
+	for _, field := range fields {
+		if field.IsPrimaryKey {
+			key.Fields = append(key.Fields, field)
+		}
+	}
+	return key
+}
+
+// Exists 是否存在主键
+func (k PrimaryKeyData) Exists() bool {
+	return len(k.Fields) > 0
+}
+
+// IsComposite 是否为复合主键
+func (k PrimaryKeyData) IsComposite() bool {
+	return len(k.Fields) > 1
+}
+
+// Columns 返回主键列名，以逗号分隔
+func (k PrimaryKeyData) Columns() string {
+	columns := make([]string, 0, len(k.Fields))
+	for _, field := range k.Fields {
+		columns = append(columns, field.ColumnName)
+	}
+	return strings.Join(columns, ", ")
+}
+
+// Where 生成 gobatis 主键条件，prefix 为参数前缀，如 "key."
+func (k PrimaryKeyData) Where(prefix string) string {
+	return k.AliasWhere("", prefix)
+}
+
+// AliasWhere 生成带表别名的 gobatis 主键条件，用于联表查询
+func (k PrimaryKeyData) AliasWhere(alias, prefix string) string {
+	if alias != "" {
+		alias += "."
+	}
+	conditions := make([]string, 0, len(k.Fields))
+	for _, field := range k.Fields {
+		conditions = append(conditions, fmt.Sprintf("%s%s = #{%s%s}", alias, field.ColumnName, prefix, field.Name))
+	}
+	return strings.Join(conditions, " AND ")
+}
+
+// Placeholders 生成 ? 占位符形式的主键条件
+func (k PrimaryKeyData) Placeholders() string {
+	conditions := make([]string, 0, len(k.Fields))
+	for _, field := range k.Fields {
+		conditions = append(conditions, field.ColumnName+" = ?")
+	}
+	return strings.Join(conditions, " AND ")
+}
+
+// supportsTupleIn 判断数据库方言是否支持 (a, b) IN ((?, ?)) 形式的行值比较
+func supportsTupleIn(dialect string) bool {
+	switch dialect {
+	case "mysql", "postgres":
+		return true
+	default:
+		return false
+	}
+}
+
+// returningKey 判断插入语句是否通过 RETURNING 回填自增主键，PostgreSQL 驱动不支持 LastInsertId
+func returningKey(key PrimaryKeyData, dialect string) bool {
+	return dialect == "postgres" && len(key.Fields) == 1 && key.Fields[0].IsAutoIncr
+}
@@ -0,0 +1,598 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	
+	"go-mapper-gen/internal/database"
+)
+
+// MigrationGenerator 根据表结构差异生成迁移脚本
+type MigrationGenerator struct {
+	dialect string // 目标方言：mysql, postgres, sqlite
+}
+
+// Migration 迁移脚本，Down 为 Up 的逆操作
+type Migration struct {
+	Up   []string
+	Down []string
+}
+
+// migrationStep 一组迁移语句及其逆操作
+type migrationStep struct {
+	up   []string
+	down []string
+}
+
+// NewMigrationGenerator 创建迁移脚本生成器
+func NewMigrationGenerator(dialect string) (*MigrationGenerator, error) {
+	switch dialect {
+	case "mysql", "postgres", "sqlite":
+		return &MigrationGenerator{dialect: dialect}, nil
+	default:
+		return nil, fmt.Errorf("不支持的迁移方言: %s, 支持的方言: mysql, postgres, sqlite", dialect)
+	}
+}
+
+// IsEmpty 判断迁移是否没有任何语句
+func (m Migration) IsEmpty() bool {
+	return len(m.Up) == 0
+}
+
+// Build 生成将 from 表结构变更为 to 表结构的迁移语句
+func (mg *MigrationGenerator) Build(from, to []database.Table) Migration {
+	// 视图没有对应的建表语句，不参与迁移
+	from, to = baseTables(from), baseTables(to)
+	diff := database.DiffTables(from, to)
+	fromMap := tablesByName(from)
+	toMap := tablesByName(to)
+	
+	added := make(map[string]bool, len(diff.AddedTables))
+	for _, name := range diff.AddedTables {
+		added[name] = true
+	}
+	removed := make(map[string]bool, len(diff.RemovedTables))
+	for _, name := range diff.RemovedTables {
+		removed[name] = true
+	}
+	
+	// 外键与建表分开处理，表之间的引用顺序不影响执行：先删除外键，最后添加外键，回滚时顺序相反
+	var steps, addForeignKeys []migrationStep
+	for _, tableDiff := range diff.ChangedTables {
+		steps = append(steps, mg.dropForeignKeys(fromMap[tableDiff.Name], tableDiff.DroppedForeignKeys)...)
+	}
+	for _, table := range from {
+		if removed[table.QualifiedName()] && mg.dialect != "sqlite" {
+			steps = append(steps, mg.dropForeignKeys(table, foreignKeyNames(table))...)
+		}
+	}
+	
+	// 新增的表按目标表结构中的顺序创建
+	for _, table := range to {
+		if added[table.QualifiedName()] {
+			steps = append(steps, migrationStep{
+				up:   mg.createTable(table),
+				down: []string{fmt.Sprintf("DROP TABLE %s;", mg.tableName(table))},
+			})
+			if mg.dialect != "sqlite" {
+				addForeignKeys = append(addForeignKeys, mg.addForeignKeys(table, foreignKeyNames(table))...)
+			}
+		}
+	}
+	
+	for _, tableDiff := range diff.ChangedTables {
+		steps = append(steps, mg.alterTable(fromMap[tableDiff.Name], toMap[tableDiff.Name], tableDiff)...)
+		addForeignKeys = append(addForeignKeys, mg.addForeignKeys(toMap[tableDiff.Name], tableDiff.AddedForeignKeys)...)
+	}
+	
+	// 删除的表按原表结构的逆序删除
+	for i := len(from) - 1; i >= 0; i-- {
+		if removed[from[i].QualifiedName()] {
+			steps = append(steps, migrationStep{
+				up:   []string{fmt.Sprintf("DROP TABLE %s;", mg.tableName(from[i]))},
+				down: mg.createTable(from[i]),
+			})
+		}
+	}
+	steps = append(steps, addForeignKeys...)
+	
+	var migration Migration
+	for _, step := range steps {
+		migration.Up = append(migration.Up, step.up...)
+	}
+	for i := len(steps) - 1; i >= 0; i-- {
+		migration.Down = append(migration.Down, steps[i].down...)
+	}
+	return migration
+}
+
+// alterTable 生成同名表的变更语句：先删除索引，再处理列和主键，最后创建索引
+func (mg *MigrationGenerator) alterTable(from, to database.Table, diff database.TableDiff) []migrationStep {
+	var steps []migrationStep
+	fromIndexes := indexesByName(from)
+	toIndexes := indexesByName(to)
+	fromColumns := columnsByName(from)
+	toColumns := columnsByName(to)
+	
+	for _, name := range diff.DroppedIndexes {
+		steps = append(steps, migrationStep{
+			up:   []string{mg.dropIndex(from, name)},
+			down: []string{mg.createIndex(mg.tableName(from), fromIndexes[name])},
+		})
+	}
+	
+	// 主键变化时先删除原主键，新增列后再添加新主键
+	var fromKey, toKey []string
+	if diff.PrimaryKey != nil {
+		fromKey, toKey = diff.PrimaryKey.From, diff.PrimaryKey.To
+		if len(fromKey) > 0 {
+			steps = append(steps, migrationStep{
+				up:   mg.dropPrimaryKey(from),
+				down: mg.addPrimaryKey(mg.tableName(from), fromKey),
+			})
+		}
+	}
+	
+	for _, name := range diff.AddedColumns {
+		col := toColumns[name]
+		steps = append(steps, migrationStep{
+			up:   []string{fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s;", mg.tableName(to), mg.columnDefinition(col))},
+			down: []string{fmt.Sprintf("ALTER TABLE %s DROP COLUMN %s;", mg.tableName(to), mg.quote(name))},
+		})
+	}
+	
+	// 同一列的类型和可空性变化合并处理
+	modified := make(map[string]bool)
+	for _, change := range diff.ColumnChanges {
+		if modified[change.Column] {
+			continue
+		}
+		modified[change.Column] = true
+		steps = append(steps, migrationStep{
+			up:   mg.modifyColumn(mg.tableName(to), fromColumns[change.Column], toColumns[change.Column]),
+			down: mg.modifyColumn(mg.tableName(to), toColumns[change.Column], fromColumns[change.Column]),
+		})
+	}
+	
+	for _, name := range diff.DroppedColumns {
+		col := fromColumns[name]
+		steps = append(steps, migrationStep{
+			up:   []string{fmt.Sprintf("ALTER TABLE %s DROP COLUMN %s;", mg.tableName(from), mg.quote(name))},
+			down: []string{fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s;", mg.tableName(from), mg.columnDefinition(col))},
+		})
+	}
+	
+	if len(toKey) > 0 {
+		steps = append(steps, migrationStep{
+			up:   mg.addPrimaryKey(mg.tableName(to), toKey),
+			down: mg.dropPrimaryKey(to),
+		})
+	}
+	
+	for _, name := range diff.AddedIndexes {
+		steps = append(steps, migrationStep{
+			up:   []string{mg.createIndex(mg.tableName(to), toIndexes[name])},
+			down: []string{mg.dropIndex(to, name)},
+		})
+	}
+	
+	return steps
+}
+
+// createTable 生成建表语句及其索引
+func (mg *MigrationGenerator) createTable(table database.Table) []string {
+	var keyColumns []string
+	for _, col := range table.Columns {
+		if col.IsPrimaryKey {
+			keyColumns = append(keyColumns, col.Name)
+		}
+	}
+	// SQLite 的自增列必须以 INTEGER PRIMARY KEY AUTOINCREMENT 的形式内联声明
+	inlineKey := mg.dialect == "sqlite" && len(keyColumns) == 1
+	
+	var definitions []string
+	for _, col := range table.Columns {
+		definition := mg.columnDefinition(col)
+		if inlineKey && col.IsPrimaryKey {
+			definition = fmt.Sprintf("%s %s PRIMARY KEY", mg.quote(col.Name), mg.columnType(col))
+			if col.IsAutoIncr {
+				definition += " AUTOINCREMENT"
+			}
+		}
+		definitions = append(definitions, "    "+definition)
+	}
+	if len(keyColumns) > 0 && !inlineKey {
+		definitions = append(definitions, fmt.Sprintf("    PRIMARY KEY (%s)", mg.quoteList(keyColumns)))
+	}
+	// SQLite 不能单独添加外键，在建表语句中声明，其他方言的外键在所有表创建后添加
+	if mg.dialect == "sqlite" {
+		for _, fk := range table.ForeignKeys {
+			definitions = append(definitions, "    "+mg.foreignKeyDefinition(fk))
+		}
+	}
+	
+	// SQLite 的表选项
+	var options []string
+	if mg.dialect == "sqlite" && table.WithoutRowid {
+		options = append(options, "WITHOUT ROWID")
+	}
+	if mg.dialect == "sqlite" && table.Strict {
+		options = append(options, "STRICT")
+	}
+	suffix := ""
+	if len(options) > 0 {
+		suffix = " " + strings.Join(options, ", ")
+	}
+	
+	statements := []string{fmt.Sprintf("CREATE TABLE %s (\n%s\n)%s;", mg.tableName(table), strings.Join(definitions, ",\n"), suffix)}
+	for _, index := range table.Indexes {
+		if !index.IsPrimary {
+			statements = append(statements, mg.createIndex(mg.tableName(table), index))
+		}
+	}
+	return statements
+}
+
+// columnDefinition 生成列定义
+func (mg *MigrationGenerator) columnDefinition(col database.Column) string {
+	parts := []string{mg.quote(col.Name), mg.columnType(col)}
+	if !col.Nullable {
+		parts = append(parts, "NOT NULL")
+	}
+	if def := mg.columnDefault(col); def != "" {
+		parts = append(parts, "DEFAULT "+def)
+	}
+	if col.IsAutoIncr && mg.dialect == "mysql" {
+		parts = append(parts, "AUTO_INCREMENT")
+	}
+	if col.Identity != "" && mg.dialect == "postgres" {
+		parts = append(parts, "GENERATED "+col.Identity+" AS IDENTITY")
+	}
+	return strings.Join(parts, " ")
+}
+
+// columnType 返回列的数据库类型。MySQL 优先使用完整的 COLUMN_TYPE，
+// 缺少时 DATA_TYPE 不含长度，字符串类型使用默认长度。PostgreSQL 根据长度和精度补全 varchar(100)、numeric(10,2) 等类型
+func (mg *MigrationGenerator) columnType(col database.Column) string {
+	typ := col.Type
+	switch mg.dialect {
+	case "mysql":
+		if col.FullType != "" {
+			return col.FullType
+		}
+		switch strings.ToLower(typ) {
+		case "varchar":
+			return "varchar(255)"
+		case "char":
+			return "char(1)"
+		}
+	case "postgres":
+		// 枚举列使用枚举类型名，枚举类型需已存在；数组和其他自定义类型使用 udt_name 记录的完整类型
+		if col.EnumName != "" {
+			return col.EnumName
+		}
+		if (typ == "ARRAY" || typ == "USER-DEFINED") && col.FullType != "" {
+			return col.FullType
+		}
+		typ = col.SizedType()
+		// 序列默认值的整数列使用 serial 类型建表
+		if col.IsAutoIncr && strings.HasPrefix(col.DefaultValue, "nextval(") {
+			switch strings.ToLower(typ) {
+			case "smallint":
+				return "smallserial"
+			case "integer":
+				return "serial"
+			case "bigint":
+				return "bigserial"
+			}
+		}
+	}
+	return typ
+}
+
+// mysqlDefaultKeywords MySQL 中不需要加引号的默认值
+var mysqlDefaultKeywords = regexp.MustCompile(`(?i)^(-?[0-9.]+|NULL|TRUE|FALSE|CURRENT_TIMESTAMP(\(\d*\))?|NOW\(\)|b'[01]*'|\(.*\))$`)
+
+// columnDefault 返回列默认值表达式
+func (mg *MigrationGenerator) columnDefault(col database.Column) string {
+	def := col.DefaultValue
+	switch {
+	case def == "":
+		return ""
+	case mg.dialect == "postgres" && strings.HasPrefix(def, "nextval("):
+		// 由 serial 类型生成
+		return ""
+	case mg.dialect == "mysql" && !mysqlDefaultKeywords.MatchString(def):
+		// INFORMATION_SCHEMA 中的字符串默认值不带引号
+		return "'" + strings.ReplaceAll(def, "'", "''") + "'"
+	}
+	return def
+}
+
+// modifyColumn 生成修改列类型或可空性的语句
+func (mg *MigrationGenerator) modifyColumn(table string, from, to database.Column) []string {
+	switch mg.dialect {
+	case "mysql":
+		return []string{fmt.Sprintf("ALTER TABLE %s MODIFY COLUMN %s;", table, mg.columnDefinition(to))}
+	case "postgres":
+		var statements []string
+		// 比较带长度、精度的完整类型，枚举和数组列使用与建表一致的类型名
+		if fromType, toType := mg.columnType(from), mg.columnType(to); !strings.EqualFold(fromType, toType) {
+			statements = append(statements, fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s TYPE %s USING %s::%s;",
+				table, mg.quote(to.Name), toType, mg.quote(to.Name), toType))
+		}
+		if from.Nullable != to.Nullable {
+			action := "SET NOT NULL"
+			if to.Nullable {
+				action = "DROP NOT NULL"
+			}
+			statements = append(statements, fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s %s;", table, mg.quote(to.Name), action))
+		}
+		return statements
+	default:
+		return []string{fmt.Sprintf("-- SQLite 不支持修改列，需要重建表 %s 以将列 %s 修改为: %s", table, mg.quote(to.Name), mg.columnDefinition(to))}
+	}
+}
+
+// addPrimaryKey 生成添加主键的语句
+func (mg *MigrationGenerator) addPrimaryKey(table string, columns []string) []string {
+	if mg.dialect == "sqlite" {
+		return []string{fmt.Sprintf("-- SQLite 不支持修改主键，需要重建表 %s 以将主键设置为 (%s)", table, mg.quoteList(columns))}
+	}
+	return []string{fmt.Sprintf("ALTER TABLE %s ADD PRIMARY KEY (%s);", table, mg.quoteList(columns))}
+}
+
+// dropPrimaryKey 生成删除主键的语句
+func (mg *MigrationGenerator) dropPrimaryKey(table database.Table) []string {
+	switch mg.dialect {
+	case "mysql":
+		return []string{fmt.Sprintf("ALTER TABLE %s DROP PRIMARY KEY;", mg.tableName(table))}
+	case "postgres":
+		name := table.Name + "_pkey"
+		for _, index := range table.Indexes {
+			if index.IsPrimary {
+				name = index.Name
+			}
+		}
+		return []string{fmt.Sprintf("ALTER TABLE %s DROP CONSTRAINT %s;", mg.tableName(table), mg.quote(name))}
+	default:
+		return []string{fmt.Sprintf("-- SQLite 不支持删除主键，需要重建表 %s", mg.tableName(table))}
+	}
+}
+
+// createIndex 生成创建索引的语句
+func (mg *MigrationGenerator) createIndex(table string, index database.Index) string {
+	unique := ""
+	if index.IsUnique {
+		unique = "UNIQUE "
+	}
+	return fmt.Sprintf("CREATE %sINDEX %s ON %s (%s);", unique, mg.quote(index.Name), table, mg.quoteList(index.Columns))
+}
+
+// dropIndex 生成删除索引的语句，PostgreSQL 和 SQLite 的索引名需要带表所在的 schema
+func (mg *MigrationGenerator) dropIndex(table database.Table, name string) string {
+	if mg.dialect == "mysql" {
+		return fmt.Sprintf("DROP INDEX %s ON %s;", mg.quote(name), mg.tableName(table))
+	}
+	name = mg.quote(name)
+	if table.Schema != "" {
+		name = mg.quote(table.Schema) + "." + name
+	}
+	return fmt.Sprintf("DROP INDEX %s;", name)
+}
+
+// foreignKeyDefinition 生成外键约束定义
+func (mg *MigrationGenerator) foreignKeyDefinition(fk database.ForeignKey) string {
+	return fmt.Sprintf("CONSTRAINT %s FOREIGN KEY (%s) REFERENCES %s (%s)",
+		mg.quote(fk.Name), mg.quoteList(fk.Columns), mg.quoteName(fk.RefTable), mg.quoteList(fk.RefColumns))
+}
+
+// addForeignKeys 生成添加外键的步骤，回滚时删除外键
+func (mg *MigrationGenerator) addForeignKeys(table database.Table, names []string) []migrationStep {
+	foreignKeys := foreignKeysByName(table)
+	steps := make([]migrationStep, 0, len(names))
+	for _, name := range names {
+		fk := foreignKeys[name]
+		steps = append(steps, migrationStep{
+			up:   []string{mg.addForeignKey(table, fk)},
+			down: []string{mg.dropForeignKey(table, fk)},
+		})
+	}
+	return steps
+}
+
+// dropForeignKeys 生成删除外键的步骤，回滚时重新添加外键
+func (mg *MigrationGenerator) dropForeignKeys(table database.Table, names []string) []migrationStep {
+	foreignKeys := foreignKeysByName(table)
+	steps := make([]migrationStep, 0, len(names))
+	for _, name := range names {
+		fk := foreignKeys[name]
+		steps = append(steps, migrationStep{
+			up:   []string{mg.dropForeignKey(table, fk)},
+			down: []string{mg.addForeignKey(table, fk)},
+		})
+	}
+	return steps
+}
+
+// addForeignKey 生成添加外键的语句
+func (mg *MigrationGenerator) addForeignKey(table database.Table, fk database.ForeignKey) string {
+	if mg.dialect == "sqlite" {
+		return fmt.Sprintf("-- SQLite 不支持添加外键，需要重建表 %s 以添加: %s", mg.tableName(table), mg.foreignKeyDefinition(fk))
+	}
+	return fmt.Sprintf("ALTER TABLE %s ADD %s;", mg.tableName(table), mg.foreignKeyDefinition(fk))
+}
+
+// dropForeignKey 生成删除外键的语句
+func (mg *MigrationGenerator) dropForeignKey(table database.Table, fk database.ForeignKey) string {
+	switch mg.dialect {
+	case "mysql":
+		return fmt.Sprintf("ALTER TABLE %s DROP FOREIGN KEY %s;", mg.tableName(table), mg.quote(fk.Name))
+	case "postgres":
+		return fmt.Sprintf("ALTER TABLE %s DROP CONSTRAINT %s;", mg.tableName(table), mg.quote(fk.Name))
+	default:
+		return fmt.Sprintf("-- SQLite 不支持删除外键，需要重建表 %s 以删除外键 %s", mg.tableName(table), mg.quote(fk.Name))
+	}
+}
+
+// quote 为标识符加引号，MySQL 使用反引号，PostgreSQL 和 SQLite 使用双引号，
+// 列名为 key、order 等关键字时语句仍然有效
+func (mg *MigrationGenerator) quote(name string) string {
+	if mg.dialect == "mysql" {
+		return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+	}
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+// quoteName 为 schema.table 形式的名称的每一部分加引号
+func (mg *MigrationGenerator) quoteName(name string) string {
+	parts := strings.Split(name, ".")
+	for i, part := range parts {
+		parts[i] = mg.quote(part)
+	}
+	return strings.Join(parts, ".")
+}
+
+// quoteList 为列名列表加引号并以逗号分隔
+func (mg *MigrationGenerator) quoteList(names []string) string {
+	quoted := make([]string, len(names))
+	for i, name := range names {
+		quoted[i] = mg.quote(name)
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// tableName 返回加引号的表名，设置了 schema 时带 schema
+func (mg *MigrationGenerator) tableName(table database.Table) string {
+	if table.Schema == "" {
+		return mg.quote(table.Name)
+	}
+	return mg.quote(table.Schema) + "." + mg.quote(table.Name)
+}
+
+// Write 将迁移脚本写入迁移目录，文件按 golang-migrate 的命名方式编号：0001_name.up.sql / 0001_name.down.sql
+func (mg *MigrationGenerator) Write(dir, name string, migration Migration) (string, string, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", "", fmt.Errorf("创建迁移目录失败: %w", err)
+	}
+	
+	version, width, err := nextMigrationVersion(dir)
+	if err != nil {
+		return "", "", err
+	}
+	
+	prefix := fmt.Sprintf("%0*d_%s", width, version, toSnakeCase(name))
+	upPath := filepath.Join(dir, prefix+".up.sql")
+	downPath := filepath.Join(dir, prefix+".down.sql")
+	
+	if err := os.WriteFile(upPath, []byte(formatMigration(migration.Up)), 0644); err != nil {
+		return "", "", fmt.Errorf("写入文件失败: %w", err)
+	}
+	if err := os.WriteFile(downPath, []byte(formatMigration(migration.Down)), 0644); err != nil {
+		return "", "", fmt.Errorf("写入文件失败: %w", err)
+	}
+	
+	return upPath, downPath, nil
+}
+
+// nextMigrationVersion 返回下一个迁移版本号及编号宽度，宽度与目录中已有的文件保持一致
+func nextMigrationVersion(dir string) (uint64, int, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return 0, 0, fmt.Errorf("读取迁移目录失败: %w", err)
+	}
+	
+	var latest uint64
+	width := 4
+	for _, entry := range entries {
+		name := entry.Name()
+		end := 0
+		for end < len(name) && name[end] >= '0' && name[end] <= '9' {
+			end++
+		}
+		if end == 0 || entry.IsDir() || !strings.HasSuffix(name, ".sql") {
+			continue
+		}
+		version, err := strconv.ParseUint(name[:end], 10, 64)
+		if err != nil {
+			continue
+		}
+		if version >= latest {
+			latest = version
+			width = end
+		}
+	}
+	
+	return latest + 1, width, nil
+}
+
+// formatMigration 将迁移语句拼接为文件内容
+func formatMigration(statements []string) string {
+	var b strings.Builder
+	b.WriteString("-- 由 go-mapper-gen 根据表结构差异生成\n\n")
+	for _, statement := range statements {
+		b.WriteString(statement)
+		b.WriteString("\n\n")
+	}
+	return strings.TrimRight(b.String(), "\n") + "\n"
+}
+
+// baseTables 返回除视图外的普通表
+func baseTables(tables []database.Table) []database.Table {
+	var result []database.Table
+	for _, table := range tables {
+		if !table.IsView() {
+			result = append(result, table)
+		}
+	}
+	return result
+}
+
+// tablesByName 按表名索引表结构
+func tablesByName(tables []database.Table) map[string]database.Table {
+	m := make(map[string]database.Table, len(tables))
+	for _, table := range tables {
+		m[table.QualifiedName()] = table
+	}
+	return m
+}
+
+// columnsByName 按列名索引列
+func columnsByName(table database.Table) map[string]database.Column {
+	m := make(map[string]database.Column, len(table.Columns))
+	for _, col := range table.Columns {
+		m[col.Name] = col
+	}
+	return m
+}
+
+// indexesByName 按索引名索引索引定义
+func indexesByName(table database.Table) map[string]database.Index {
+	m := make(map[string]database.Index, len(table.Indexes))
+	for _, index := range table.Indexes {
+		m[index.Name] = index
+	}
+	return m
+}
+
+// foreignKeysByName 按约束名索引外键
+func foreignKeysByName(table database.Table) map[string]database.ForeignKey {
+	m := make(map[string]database.ForeignKey, len(table.ForeignKeys))
+	for _, fk := range table.ForeignKeys {
+		m[fk.Name] = fk
+	}
+	return m
+}
+
+// foreignKeyNames 返回表的全部外键名，保持定义顺序
+func foreignKeyNames(table database.Table) []string {
+	names := make([]string, 0, len(table.ForeignKeys))
+	for _, fk := range table.ForeignKeys {
+		names = append(names, fk.Name)
+	}
+	return names
+}
@@ -0,0 +1,356 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"go-mapper-gen/internal/database"
+)
+
+func TestMigrationBuild(t *testing.T) {
+	from := []database.Table{
+		{
+			Name: "users",
+			Columns: []database.Column{
+				{Name: "id", Type: "bigint", IsPrimaryKey: true, IsAutoIncr: true},
+				{Name: "email", Type: "varchar"},
+				{Name: "age", Type: "int", Nullable: true},
+			},
+			Indexes: []database.Index{
+				{Name: "PRIMARY", Columns: []string{"id"}, IsUnique: true, IsPrimary: true},
+			},
+		},
+	}
+	to := []database.Table{
+		{
+			Name: "users",
+			Columns: []database.Column{
+				{Name: "id", Type: "bigint", IsPrimaryKey: true, IsAutoIncr: true},
+				{Name: "email", Type: "varchar", Nullable: true},
+				{Name: "status", Type: "varchar", DefaultValue: "active"},
+			},
+			Indexes: []database.Index{
+				{Name: "PRIMARY", Columns: []string{"id"}, IsUnique: true, IsPrimary: true},
+				{Name: "uk_email", Columns: []string{"email"}, IsUnique: true},
+			},
+		},
+		{
+			Name: "tags",
+			Columns: []database.Column{
+				{Name: "id", Type: "int", IsPrimaryKey: true},
+			},
+		},
+	}
+	
+	mg, err := NewMigrationGenerator("mysql")
+	if err != nil {
+		t.Fatalf("创建迁移生成器失败: %v", err)
+	}
+	migration := mg.Build(from, to)
+	
+	expectedUp := []string{
+		"CREATE TABLE `tags` (\n    `id` int NOT NULL,\n    PRIMARY KEY (`id`)\n);",
+		"ALTER TABLE `users` ADD COLUMN `status` varchar(255) NOT NULL DEFAULT 'active';",
+		"ALTER TABLE `users` MODIFY COLUMN `email` varchar(255);",
+		"ALTER TABLE `users` DROP COLUMN `age`;",
+		"CREATE UNIQUE INDEX `uk_email` ON `users` (`email`);",
+	}
+	if !reflect.DeepEqual(migration.Up, expectedUp) {
+		t.Errorf("up 脚本不正确:\n期望 %q\n实际 %q", expectedUp, migration.Up)
+	}
+	
+	expectedDown := []string{
+		"DROP INDEX `uk_email` ON `users`;",
+		"ALTER TABLE `users` ADD COLUMN `age` int;",
+		"ALTER TABLE `users` MODIFY COLUMN `email` varchar(255) NOT NULL;",
+		"ALTER TABLE `users` DROP COLUMN `status`;",
+		"DROP TABLE `tags`;",
+	}
+	if !reflect.DeepEqual(migration.Down, expectedDown) {
+		t.Errorf("down 脚本不正确:\n期望 %q\n实际 %q", expectedDown, migration.Down)
+	}
+	
+	if !mg.Build(from, from).IsEmpty() {
+		t.Error("相同表结构不应生成迁移语句")
+	}
+}
+
+func TestMigrationWrite(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "000007_init.up.sql"), nil, 0644); err != nil {
+		t.Fatalf("创建迁移文件失败: %v", err)
+	}
+	
+	mg, err := NewMigrationGenerator("postgres")
+	if err != nil {
+		t.Fatalf("创建迁移生成器失败: %v", err)
+	}
+	upPath, downPath, err := mg.Write(dir, "addUsers", Migration{Up: []string{"SELECT 1;"}, Down: []string{"SELECT 2;"}})
+	if err != nil {
+		t.Fatalf("写入迁移脚本失败: %v", err)
+	}
+	
+	if filepath.Base(upPath) != "000008_add_users.up.sql" || filepath.Base(downPath) != "000008_add_users.down.sql" {
+		t.Errorf("迁移文件名不正确: %s, %s", upPath, downPath)
+	}
+}
+
+func TestMigrationPostgresIdentity(t *testing.T) {
+	to := []database.Table{
+		{
+			Name: "accounts",
+			Columns: []database.Column{
+				{Name: "id", Type: "bigint", IsPrimaryKey: true, IsAutoIncr: true, Identity: "BY DEFAULT", Sequence: "accounts_id_seq"},
+				{Name: "serial_no", Type: "integer", IsAutoIncr: true, DefaultValue: "nextval('accounts_serial_no_seq'::regclass)", Sequence: "accounts_serial_no_seq"},
+			},
+		},
+	}
+	
+	mg, err := NewMigrationGenerator("postgres")
+	if err != nil {
+		t.Fatalf("创建迁移生成器失败: %v", err)
+	}
+	migration := mg.Build(nil, to)
+	
+	expected := "CREATE TABLE \"accounts\" (\n    \"id\" bigint NOT NULL GENERATED BY DEFAULT AS IDENTITY,\n    \"serial_no\" serial NOT NULL,\n    PRIMARY KEY (\"id\")\n);"
+	if len(migration.Up) != 1 || migration.Up[0] != expected {
+		t.Errorf("up 脚本不正确:\n期望 %q\n实际 %q", expected, migration.Up)
+	}
+}
+
+func TestMigrationPostgresColumnTypes(t *testing.T) {
+	from := []database.Table{
+		{
+			Name: "orders",
+			Columns: []database.Column{
+				{Name: "id", Type: "integer", IsPrimaryKey: true, Precision: 32},
+				{Name: "code", Type: "character varying", Length: 50},
+				{Name: "status", Type: "USER-DEFINED", FullType: "order_status", EnumName: "order_status"},
+			},
+		},
+	}
+	to := []database.Table{
+		{
+			Name: "orders",
+			Columns: []database.Column{
+				{Name: "id", Type: "integer", IsPrimaryKey: true, Precision: 32},
+				{Name: "code", Type: "character varying", Length: 100},
+				{Name: "status", Type: "USER-DEFINED", FullType: "order_state", EnumName: "order_state"},
+				{Name: "amount", Type: "numeric", Precision: 10, Scale: 2, Nullable: true},
+			},
+		},
+	}
+	
+	mg, err := NewMigrationGenerator("postgres")
+	if err != nil {
+		t.Fatalf("创建迁移生成器失败: %v", err)
+	}
+	migration := mg.Build(from, to)
+	
+	expectedUp := []string{
+		"ALTER TABLE \"orders\" ADD COLUMN \"amount\" numeric(10,2);",
+		"ALTER TABLE \"orders\" ALTER COLUMN \"code\" TYPE character varying(100) USING \"code\"::character varying(100);",
+		"ALTER TABLE \"orders\" ALTER COLUMN \"status\" TYPE order_state USING \"status\"::order_state;",
+	}
+	if !reflect.DeepEqual(migration.Up, expectedUp) {
+		t.Errorf("up 脚本不正确:\n期望 %q\n实际 %q", expectedUp, migration.Up)
+	}
+	
+	created := mg.Build(nil, to)
+	expected := "CREATE TABLE \"orders\" (\n    \"id\" integer NOT NULL,\n    \"code\" character varying(100) NOT NULL,\n" +
+		"    \"status\" order_state NOT NULL,\n    \"amount\" numeric(10,2),\n    PRIMARY KEY (\"id\")\n);"
+	if len(created.Up) != 1 || created.Up[0] != expected {
+		t.Errorf("建表语句不正确:\n期望 %q\n实际 %q", expected, created.Up)
+	}
+}
+
+func TestMigrationPostgresSchema(t *testing.T) {
+	from := []database.Table{
+		{
+			Schema: "billing",
+			Name:   "invoices",
+			Columns: []database.Column{
+				{Name: "id", Type: "bigint", IsPrimaryKey: true},
+				{Name: "code", Type: "text"},
+				{Name: "memo", Type: "text", Nullable: true},
+			},
+			Indexes: []database.Index{
+				{Name: "idx_invoices_code", Columns: []string{"code"}},
+			},
+		},
+	}
+	to := []database.Table{
+		{
+			Schema: "billing",
+			Name:   "invoices",
+			Columns: []database.Column{
+				{Name: "id", Type: "bigint", IsPrimaryKey: true},
+				{Name: "code", Type: "text", Nullable: true},
+				{Name: "total", Type: "bigint"},
+			},
+			Indexes: []database.Index{
+				{Name: "uk_invoices_code", Columns: []string{"code"}, IsUnique: true},
+			},
+		},
+	}
+	
+	mg, err := NewMigrationGenerator("postgres")
+	if err != nil {
+		t.Fatalf("创建迁移生成器失败: %v", err)
+	}
+	migration := mg.Build(from, to)
+	
+	expectedUp := []string{
+		"DROP INDEX \"billing\".\"idx_invoices_code\";",
+		"ALTER TABLE \"billing\".\"invoices\" ADD COLUMN \"total\" bigint NOT NULL;",
+		"ALTER TABLE \"billing\".\"invoices\" ALTER COLUMN \"code\" DROP NOT NULL;",
+		"ALTER TABLE \"billing\".\"invoices\" DROP COLUMN \"memo\";",
+		"CREATE UNIQUE INDEX \"uk_invoices_code\" ON \"billing\".\"invoices\" (\"code\");",
+	}
+	if !reflect.DeepEqual(migration.Up, expectedUp) {
+		t.Errorf("up 脚本不正确:\n期望 %q\n实际 %q", expectedUp, migration.Up)
+	}
+	
+	expectedDown := []string{
+		"DROP INDEX \"billing\".\"uk_invoices_code\";",
+		"ALTER TABLE \"billing\".\"invoices\" ADD COLUMN \"memo\" text;",
+		"ALTER TABLE \"billing\".\"invoices\" ALTER COLUMN \"code\" SET NOT NULL;",
+		"ALTER TABLE \"billing\".\"invoices\" DROP COLUMN \"total\";",
+		"CREATE INDEX \"idx_invoices_code\" ON \"billing\".\"invoices\" (\"code\");",
+	}
+	if !reflect.DeepEqual(migration.Down, expectedDown) {
+		t.Errorf("down 脚本不正确:\n期望 %q\n实际 %q", expectedDown, migration.Down)
+	}
+}
+
+func TestMigrationQuoteIdentifiers(t *testing.T) {
+	to := []database.Table{
+		{
+			Name: "order",
+			Columns: []database.Column{
+				{Name: "key", Type: "varchar", IsPrimaryKey: true},
+				{Name: "type", Type: "int"},
+			},
+			Indexes: []database.Index{{Name: "index", Columns: []string{"type"}}},
+		},
+	}
+	
+	tests := []struct {
+		dialect  string
+		expected []string
+	}{
+		{"mysql", []string{
+			"CREATE TABLE `order` (\n    `key` varchar(255) NOT NULL,\n    `type` int NOT NULL,\n    PRIMARY KEY (`key`)\n);",
+			"CREATE INDEX `index` ON `order` (`type`);",
+		}},
+		{"sqlite", []string{
+			"CREATE TABLE \"order\" (\n    \"key\" varchar PRIMARY KEY,\n    \"type\" int NOT NULL\n);",
+			"CREATE INDEX \"index\" ON \"order\" (\"type\");",
+		}},
+	}
+	for _, tt := range tests {
+		mg, err := NewMigrationGenerator(tt.dialect)
+		if err != nil {
+			t.Fatalf("创建迁移生成器失败: %v", err)
+		}
+		if migration := mg.Build(nil, to); !reflect.DeepEqual(migration.Up, tt.expected) {
+			t.Errorf("%s 的标识符未加引号:\n期望 %q\n实际 %q", tt.dialect, tt.expected, migration.Up)
+		}
+	}
+}
+
+func TestMigrationForeignKeys(t *testing.T) {
+	users := database.Table{
+		Name:    "users",
+		Columns: []database.Column{{Name: "id", Type: "bigint", IsPrimaryKey: true}},
+	}
+	orders := database.Table{
+		Name: "orders",
+		Columns: []database.Column{
+			{Name: "id", Type: "bigint", IsPrimaryKey: true},
+			{Name: "user_id", Type: "bigint"},
+		},
+		ForeignKeys: []database.ForeignKey{
+			{Name: "fk_orders_user", Columns: []string{"user_id"}, RefTable: "users", RefColumns: []string{"id"}},
+		},
+	}
+	
+	mg, err := NewMigrationGenerator("postgres")
+	if err != nil {
+		t.Fatalf("创建迁移生成器失败: %v", err)
+	}
+	
+	// 引用表在快照中排在后面时，外键在所有表创建后添加，回滚时先删除外键
+	migration := mg.Build(nil, []database.Table{orders, users})
+	expectedUp := []string{
+		"CREATE TABLE \"orders\" (\n    \"id\" bigint NOT NULL,\n    \"user_id\" bigint NOT NULL,\n    PRIMARY KEY (\"id\")\n);",
+		"CREATE TABLE \"users\" (\n    \"id\" bigint NOT NULL,\n    PRIMARY KEY (\"id\")\n);",
+		"ALTER TABLE \"orders\" ADD CONSTRAINT \"fk_orders_user\" FOREIGN KEY (\"user_id\") REFERENCES \"users\" (\"id\");",
+	}
+	if !reflect.DeepEqual(migration.Up, expectedUp) {
+		t.Errorf("up 脚本不正确:\n期望 %q\n实际 %q", expectedUp, migration.Up)
+	}
+	expectedDown := []string{
+		"ALTER TABLE \"orders\" DROP CONSTRAINT \"fk_orders_user\";",
+		"DROP TABLE \"users\";",
+		"DROP TABLE \"orders\";",
+	}
+	if !reflect.DeepEqual(migration.Down, expectedDown) {
+		t.Errorf("down 脚本不正确:\n期望 %q\n实际 %q", expectedDown, migration.Down)
+	}
+	
+	// 已有表新增外键
+	plainOrders := orders
+	plainOrders.ForeignKeys = nil
+	migration = mg.Build([]database.Table{plainOrders, users}, []database.Table{orders, users})
+	expectedUp = []string{"ALTER TABLE \"orders\" ADD CONSTRAINT \"fk_orders_user\" FOREIGN KEY (\"user_id\") REFERENCES \"users\" (\"id\");"}
+	if !reflect.DeepEqual(migration.Up, expectedUp) {
+		t.Errorf("新增外键的 up 脚本不正确:\n期望 %q\n实际 %q", expectedUp, migration.Up)
+	}
+	
+	// 删除表前先删除其外键
+
+	mysql, err := NewMigrationGenerator("mysql")
+	if err != nil {
+		t.Fatalf("创建迁移生成器失败: %v", err)
+	}
+	migration = mysql.Build([]database.Table{users, orders}, nil)
+	expectedUp = []string{
+		"ALTER TABLE `orders` DROP FOREIGN KEY `fk_orders_user`;",
+		"DROP TABLE `orders`;",
+		"DROP TABLE `users`;",
+	}
+	if !reflect.DeepEqual(migration.Up, expectedUp) {
+		t.Errorf("删除表的 up 脚本不正确:\n期望 %q\n实际 %q", expectedUp, migration.Up)
+	}
+	if len(migration.Down) != 3 || !strings.HasPrefix(migration.Down[2], "ALTER TABLE `orders` ADD CONSTRAINT `fk_orders_user`") {
+		t.Errorf("删除表的 down 脚本应最后添加外键，实际为 %q", migration.Down)
+	}
+}
+
+func TestMigrationSQLiteTableOptions(t *testing.T) {
+	to := []database.Table{
+		{
+			Name: "counters",
+			Columns: []database.Column{
+				{Name: "name", Type: "TEXT", IsPrimaryKey: true},
+				{Name: "value", Type: "INTEGER"},
+			},
+			Strict:       true,
+			WithoutRowid: true,
+		},
+	}
+	
+	mg, err := NewMigrationGenerator("sqlite")
+	if err != nil {
+		t.Fatalf("创建迁移生成器失败: %v", err)
+	}
+	migration := mg.Build(nil, to)
+	
+	if len(migration.Up) != 1 || !strings.HasSuffix(migration.Up[0], ") WITHOUT ROWID, STRICT;") {
+		t.Errorf("期望建表语句包含 WITHOUT ROWID 和 STRICT，实际为 %q", migration.Up)
+	}
+}
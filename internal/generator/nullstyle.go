@@ -0,0 +1,98 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+)
+
+// sqlNullTypes 基础类型对应的 database/sql 可空类型，未列出的类型在 sql_null 风格下回退为指针
+var sqlNullTypes = map[string]string{
+	"string":    "sql.NullString",
+	"int":       "sql.NullInt64",
+	"int64":     "sql.NullInt64",
+	"uint32":    "sql.NullInt64",
+	"int32":     "sql.NullInt32",
+	"uint16":    "sql.NullInt32",
+	"int16":     "sql.NullInt16",
+	"int8":      "sql.NullInt16",
+	"uint8":     "sql.NullByte",
+	"byte":      "sql.NullByte",
+	"float32":   "sql.NullFloat64",
+	"float64":   "sql.NullFloat64",
+	"bool":      "sql.NullBool",
+	"time.Time": "sql.NullTime",
+}
+
+// sqlNullBaseTypes database/sql 可空类型对应的值类型，用于查询参数
+var sqlNullBaseTypes = map[string]string{
+	"sql.NullString":  "string",
+	"sql.NullInt64":   "int64",
+	"sql.NullInt32":   "int32",
+	"sql.NullInt16":   "int16",
+	"sql.NullByte":    "byte",
+	"sql.NullFloat64": "float64",
+	"sql.NullBool":    "bool",
+	"sql.NullTime":    "time.Time",
+}
+
+// nullableGoType 按 null_style 返回可空列的 Go 类型，goType 为不可空的基础类型
+func nullableGoType(goType, style string) string {
+	switch style {
+	case "zero_value":
+		return goType
+	case "generic":
+		return fmt.Sprintf("sql.Null[%s]", goType)
+	case "sql_null":
+		if nullType, ok := sqlNullTypes[goType]; ok {
+			return nullType
+		}
+	}
+	return "*" + goType
+}
+
+// nonNullGoType 去掉可空包装，返回值类型，如 *string、sql.NullString、sql.Null[string] 都返回 string
+func nonNullGoType(goType string) string {
+	if baseType, ok := sqlNullBaseTypes[goType]; ok {
+		return baseType
+	}
+	if strings.HasPrefix(goType, "sql.Null[") && strings.HasSuffix(goType, "]") {
+		return goType[len("sql.Null[") : len(goType)-1]
+	}
+	return strings.TrimPrefix(goType, "*")
+}
+
+// isSQLNullType 判断是否为带 Valid 字段的 database/sql 可空类型
+func isSQLNullType(goType string) bool {
+	return strings.HasPrefix(goType, "sql.Null")
+}
+
+// zeroValueLiterals zero_value 风格下可空列的值类型对应的零值字面量，字段为零值时表示 NULL
+var zeroValueLiterals = map[string]string{
+	"string":  "''",
+	"int":     "0",
+	"int8":    "0",
+	"int16":   "0",
+	"int32":   "0",
+	"int64":   "0",
+	"uint":    "0",
+	"uint8":   "0",
+	"uint16":  "0",
+	"uint32":  "0",
+	"uint64":  "0",
+	"byte":    "0",
+	"float32": "0",
+	"float64": "0",
+}
+
+// NotNullTest 返回 XML <if test> 中判断字段有值的表达式，prefix 为参数前缀，如 record.
+// zero_value 风格的可空字段是值类型，与零值比较
+func (f FieldData) NotNullTest(prefix string) string {
+	name := prefix + f.Name
+	if f.Nullable && isSQLNullType(f.Type) {
+		return fmt.Sprintf("%s != null and %s.Valid", name, name)
+	}
+	if zero, ok := zeroValueLiterals[f.Type]; ok && f.Nullable {
+		return fmt.Sprintf("%s != %s", name, zero)
+	}
+	return name + " != null"
+}
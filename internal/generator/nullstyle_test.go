@@ -0,0 +1,77 @@
+package generator
+
+import (
+	"testing"
+
+	"go-mapper-gen/internal/config"
+	"go-mapper-gen/internal/database"
+)
+
+func TestApplyNullStyle(t *testing.T) {
+	table := database.Table{Name: "users", Columns: []database.Column{
+		{Name: "id", Type: "int", GoType: "int"},
+		{Name: "name", Type: "varchar", GoType: "*string", Nullable: true},
+		{Name: "age", Type: "smallint", GoType: "*uint16", Nullable: true},
+		{Name: "born", Type: "year", GoType: "*uint64", Nullable: true},
+		{Name: "tags", Type: "ARRAY", GoType: "pq.StringArray", Nullable: true},
+		{Name: "price", Type: "decimal", GoType: "*float64", Nullable: true},
+	}}
+	types := config.TypesConfig{
+		Mappings: []config.TypeMapping{{DBType: "decimal", GoType: "github.com/shopspring/decimal.Decimal"}},
+	}
+	
+	tests := []struct {
+		style    string
+		expected []string
+	}{
+		{"", []string{"int", "*string", "*uint16", "*uint64", "pq.StringArray", "*decimal.Decimal"}},
+		{"sql_null", []string{"int", "sql.NullString", "sql.NullInt32", "*uint64", "pq.StringArray", "*decimal.Decimal"}},
+		{"generic", []string{"int", "sql.Null[string]", "sql.Null[uint16]", "sql.Null[uint64]", "pq.StringArray", "sql.Null[decimal.Decimal]"}},
+		{"zero_value", []string{"int", "string", "uint16", "uint64", "pq.StringArray", "decimal.Decimal"}},
+	}
+	for _, tt := range tests {
+		cfg := &config.Config{Types: types, Options: config.OptionsConfig{NullStyle: tt.style}}
+		mapped := applyTypeMappings([]database.Table{table}, cfg)
+		for i, col := range mapped[0].Columns {
+			if col.GoType != tt.expected[i] {
+				t.Errorf("%s 风格的列 %s: 期望 %s，实际为 %s", tt.style, col.Name, tt.expected[i], col.GoType)
+			}
+		}
+	}
+}
+
+func TestNullStyleFieldTypes(t *testing.T) {
+	if goType := qualifyModelType("sql.Null[UsersStatus]"); goType != "sql.Null[model.UsersStatus]" {
+		t.Errorf("期望泛型参数添加包名，实际为 %s", goType)
+	}
+	for _, goType := range []string{"*string", "sql.NullString", "sql.Null[string]", "string"} {
+		if base := nonNullGoType(goType); base != "string" {
+			t.Errorf("期望 %s 的值类型为 string，实际为 %s", goType, base)
+		}
+	}
+	
+	field := FieldData{Name: "Email", Type: "sql.NullString", Nullable: true}
+	if test := field.NotNullTest("record."); test != "record.Email != null and record.Email.Valid" {
+		t.Errorf("期望 sql.Null 类型判断 Valid，实际为 %s", test)
+	}
+	field = FieldData{Name: "Email", Type: "*string", Nullable: true}
+	if test := field.NotNullTest(""); test != "Email != null" {
+		t.Errorf("期望指针类型判断 null，实际为 %s", test)
+	}
+	
+	// zero_value 风格的可空字段与零值比较，不可空字段仍然判断 null
+	zeroTests := []struct {
+		field    FieldData
+		expected string
+	}{
+		{FieldData{Name: "Email", Type: "string", Nullable: true}, "record.Email != ''"},
+		{FieldData{Name: "Age", Type: "uint16", Nullable: true}, "record.Age != 0"},
+		{FieldData{Name: "CreatedAt", Type: "time.Time", Nullable: true}, "record.CreatedAt != null"},
+		{FieldData{Name: "Name", Type: "string"}, "record.Name != null"},
+	}
+	for _, tt := range zeroTests {
+		if test := tt.field.NotNullTest("record."); test != tt.expected {
+			t.Errorf("字段 %s: 期望 %s，实际为 %s", tt.field.Name, tt.expected, test)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package generator
+
+import (
+	"strings"
+	
+	"go-mapper-gen/internal/config"
+	"go-mapper-gen/internal/database"
+)
+
+// RelationData 基于外键生成的关联关系模板数据
+type RelationData struct {
+	Property      string      // 模型中的关联字段名，如 User、OrderItems
+	IsCollection  bool        // true 为一对多（collection），false 为多对一（association）
+	StructName    string      // 关联结构体名
+	TableName     string      // 关联表名
+	ForeignKey    string      // 来源外键名
+	Columns       []string    // 本表参与关联的列
+	RefColumns    []string    // 关联表参与关联的列，与 Columns 一一对应
+	Fields        []FieldData // 关联表字段
+	ColumnPrefix  string      // 关联表列在联表查询中的别名前缀
+	MethodName    string      // 联表查询方法名，如 GetOrdersWithOrderItems
+	ResultMapName string      // 联表查询结果映射名
+	Tag           string      // 模型字段标签
+}
+
+// buildRelations 根据外键构建关联关系：本表外键生成 association，其他表引用本表的外键生成 collection。
+// 只有参与生成的表之间才会建立关联，保证关联结构体一定存在。
+func buildRelations(table database.Table, tables []database.Table, cfg *config.Config) []RelationData {
+	tableStruct := structName(table, cfg)
+	tableMap := make(map[string]database.Table, len(tables))
+	for _, t := range tables {
+		tableMap[t.QualifiedName()] = t
+	}
+	
+	used := make(map[string]bool)
+	for _, col := range table.Columns {
+		used[toPascalCase(col.Name)] = true
+	}
+	
+	var relations []RelationData
+	
+	// 多对一：本表外键引用其他表
+	for _, fk := range table.ForeignKeys {
+		refTable, ok := tableMap[fk.RefTable]
+		if !ok || len(fk.RefColumns) != len(fk.Columns) {
+			continue
+		}
+		refStruct := structName(refTable, cfg)
+		
+		property := refStruct
+		if len(fk.Columns) == 1 && strings.HasSuffix(strings.ToLower(fk.Columns[0]), "_id") {
+			property = toPascalCase(fk.Columns[0][:len(fk.Columns[0])-3])
+		}
+		property = uniqueProperty(used, property, fk.Columns)
+		
+		relations = append(relations, newRelation(tableStruct, property, false, refTable, fk, fk.Columns, fk.RefColumns, cfg))
+	}
+	
+	// 一对多：其他表的外键引用本表
+	for _, child := range tables {
+		for _, fk := range child.ForeignKeys {
+			if fk.RefTable != table.QualifiedName() || len(fk.RefColumns) != len(fk.Columns) {
+				continue
+			}
+			childStruct := structName(child, cfg)
+			property := uniqueProperty(used, childStruct, fk.Columns)
+			
+			relations = append(relations, newRelation(tableStruct, property, true, child, fk, fk.RefColumns, fk.Columns, cfg))
+		}
+	}
+	
+	return relations
+}
+
+// newRelation 创建关联关系模板数据
+func newRelation(ownerStruct, property string, collection bool, related database.Table, fk database.ForeignKey, columns, refColumns []string, cfg *config.Config) RelationData {
+	relation := RelationData{
+		Property:      property,
+		IsCollection:  collection,
+		StructName:    structName(related, cfg),
+		TableName:     related.QualifiedName(),
+		ForeignKey:    fk.Name,
+		Columns:       columns,
+		RefColumns:    refColumns,
+		ColumnPrefix:  toSnakeCase(property) + "__",
+		MethodName:    "Get" + ownerStruct + "With" + property,
+		ResultMapName: ownerStruct + "With" + property + "ResultMap",
+	}
+	
+	for _, col := range related.Columns {
+		relation.Fields = append(relation.Fields, FieldData{
+			Name:         toPascalCase(col.Name),
+			Type:         col.GoType,
+			ColumnName:   col.Name,
+			IsPrimaryKey: col.IsPrimaryKey,
+		})
+	}
+	
+	return relation
+}
+
+// uniqueProperty 生成不与已有字段冲突的关联字段名，冲突时追加外键列名
+func uniqueProperty(used map[string]bool, property string, columns []string) string {
+	if used[property] {
+		var names []string
+		for _, column := range columns {
+			names = append(names, toPascalCase(column))
+		}
+		property = property + "By" + strings.Join(names, "And")
+	}
+	used[property] = true
+	return property
+}
+
+// FieldType 返回模型中关联字段的类型
+func (r RelationData) FieldType() string {
+	if r.IsCollection {
+		return "[]*" + r.StructName
+	}
+	return "*" + r.StructName
+}
+
+// JoinOn 生成联表条件，本表别名为 t，关联表别名为 r
+func (r RelationData) JoinOn() string {
+	conditions := make([]string, 0, len(r.Columns))
+	for i, column := range r.Columns {
+		conditions = append(conditions, "r."+r.RefColumns[i]+" = t."+column)
+	}
+	return strings.Join(conditions, " AND ")
+}
@@ -0,0 +1,45 @@
+package generator
+
+import (
+	"testing"
+
+	"go-mapper-gen/internal/config"
+	"go-mapper-gen/internal/database"
+)
+
+func TestSchemaQualifiedTables(t *testing.T) {
+	cfg := &config.Config{Database: config.DatabaseConfig{Schemas: []string{"public", "billing"}}}
+	users := database.Table{Schema: "public", Name: "users", Columns: []database.Column{
+		{Name: "id", Type: "int", GoType: "int", IsPrimaryKey: true},
+	}}
+	billingUsers := database.Table{Schema: "billing", Name: "users", Columns: []database.Column{
+		{Name: "id", Type: "int", GoType: "int", IsPrimaryKey: true},
+		{Name: "user_id", Type: "int", GoType: "int"},
+	}, ForeignKeys: []database.ForeignKey{
+		{Name: "fk_user", Columns: []string{"user_id"}, RefTable: "public.users", RefColumns: []string{"id"}},
+	}}
+	tables := []database.Table{users, billingUsers}
+	
+	if name := structName(users, cfg); name != "Users" {
+		t.Errorf("期望第一个 schema 的结构体名为 Users，实际为 %s", name)
+	}
+	if name := structName(billingUsers, cfg); name != "BillingUsers" {
+		t.Errorf("期望其他 schema 的结构体名为 BillingUsers，实际为 %s", name)
+	}
+	
+	relations := buildRelations(users, tables, cfg)
+	if len(relations) != 1 || relations[0].StructName != "BillingUsers" || relations[0].TableName != "billing.users" {
+		t.Errorf("期望按带 schema 的表名建立关联: %+v", relations)
+	}
+	
+	data := NewGobatisXMLGenerator(cfg).prepareTemplateData(billingUsers)
+	if data.TableName != "billing.users" {
+		t.Errorf("期望 SQL 使用带 schema 的表名，实际为 %s", data.TableName)
+	}
+	
+	split := *cfg
+	split.Output.SplitSchemas = true
+	if name := structName(billingUsers, &split); name != "Users" {
+		t.Errorf("期望按 schema 分包时结构体名不加 schema 前缀，实际为 %s", name)
+	}
+}
@@ -27,7 +27,13 @@ type SQLData struct {
 	StructName    string
 	Fields        []FieldData
 	PrimaryKey    FieldData
+	Key           PrimaryKeyData
 	HasPrimaryKey bool
+	UseTupleIn    bool
+	ReturningKey  bool
+	ReadOnly      bool // 视图只生成查询语句
+	Refreshable   bool // 物化视图生成刷新语句
+	OrderBy       string
 	InsertFields  []FieldData
 	UpdateFields  []FieldData
 }
@@ -51,17 +57,19 @@ func (sg *SQLGenerator) Generate(table database.Table) error {
 		return fmt.Errorf("写入文件失败: %w", err)
 	}
 	
-	fmt.Printf("  生成 SQL 文件: %s\n", filepath)
+	fmt.Printf("  生成 SQL 文件: %s\n", outputPath(sg.config, filepath))
 	return nil
 }
 
 // prepareTemplateData 准备模板数据
 func (sg *SQLGenerator) prepareTemplateData(table database.Table) SQLData {
-	structName := toPascalCase(removeTablePrefix(table.Name, sg.config.Tables.Prefix))
+	structName := structName(table, sg.config)
 	
 	data := SQLData{
-		TableName:  table.Name,
-		StructName: structName,
+		TableName:   table.QualifiedName(),
+		StructName:  structName,
+		ReadOnly:    table.IsView(),
+		Refreshable: table.Kind == database.KindMaterializedView,
 	}
 	
 	// 处理字段
@@ -69,13 +77,21 @@ func (sg *SQLGenerator) prepareTemplateData(table database.Table) SQLData {
 		field := FieldData{
 			Name:         col.Name,
 			Type:         col.Type,
+			ColumnName:   col.Name,
 			Comment:      col.Comment,
 			IsPrimaryKey: col.IsPrimaryKey,
+			IsAutoIncr:   col.IsAutoIncr,
+			Nullable:     col.Nullable,
+			IsGenerated:  col.Generated != "",
+			Length:       col.Length,
+			Precision:    col.Precision,
+			Scale:        col.Scale,
 		}
 		
-		if col.IsPrimaryKey {
-			data.PrimaryKey = field
-			data.HasPrimaryKey = true
+		// 生成列由数据库计算，不能插入和更新
+		if col.Generated != "" {
+			data.Fields = append(data.Fields, field)
+			continue
 		}
 		
 		// 非自增字段用于插入
@@ -91,13 +107,25 @@ func (sg *SQLGenerator) prepareTemplateData(table database.Table) SQLData {
 		data.Fields = append(data.Fields, field)
 	}
 	
+	// 处理主键
+	data.Key = newPrimaryKeyData(structName, data.Fields)
+	data.HasPrimaryKey = data.Key.Exists()
+	data.UseTupleIn = supportsTupleIn(sg.config.Database.SQLDialect())
+	data.ReturningKey = returningKey(data.Key, sg.config.Database.SQLDialect())
+	if data.HasPrimaryKey {
+		data.PrimaryKey = data.Key.Fields[0]
+		data.OrderBy = data.Key.Columns()
+	} else if len(data.Fields) > 0 {
+		data.OrderBy = data.Fields[0].Name
+	}
+	
 	return data
 }
 
 // generateCode 生成代码
 func (sg *SQLGenerator) generateCode(data SQLData) (string, error) {
 	tmpl := `-- {{ .StructName }} 相关 SQL 语句
--- 表名: {{ .TableName }}
+-- {{ if .ReadOnly }}视图{{ else }}表{{ end }}名: {{ .TableName }}
 
 -- 查询所有记录
 SELECT {{ range $i, $field := .Fields }}{{ if $i }}, {{ end }}{{ $field.Name }}{{ end }}
@@ -107,9 +135,13 @@ FROM {{ .TableName }};
 -- 根据主键查询
 SELECT {{ range $i, $field := .Fields }}{{ if $i }}, {{ end }}{{ $field.Name }}{{ end }}
 FROM {{ .TableName }}
-WHERE {{ .PrimaryKey.Name }} = ?;
+WHERE {{ .Key.Placeholders }};
 {{ end }}
-
+{{ if .Refreshable }}
+-- 刷新物化视图
+REFRESH MATERIALIZED VIEW {{ .TableName }};
+{{ end }}
+{{- if not .ReadOnly }}
 -- 插入记录
 INSERT INTO {{ .TableName }} (
 {{ range $i, $field := .InsertFields }}{{ if $i }},
@@ -117,7 +149,8 @@ INSERT INTO {{ .TableName }} (
 ) VALUES (
 {{ range $i, $field := .InsertFields }}{{ if $i }},
 {{ end }}    ?{{ end }}
-);
+){{ if .ReturningKey }}
+RETURNING {{ .PrimaryKey.Name }}{{ end }};
 
 -- 批量插入记录
 INSERT INTO {{ .TableName }} (
@@ -131,21 +164,23 @@ INSERT INTO {{ .TableName }} (
 UPDATE {{ .TableName }}
 SET {{ range $i, $field := .UpdateFields }}{{ if $i }},
     {{ end }}{{ $field.Name }} = ?{{ end }}
-WHERE {{ .PrimaryKey.Name }} = ?;
+WHERE {{ .Key.Placeholders }};
 
 -- 根据主键删除
 DELETE FROM {{ .TableName }}
-WHERE {{ .PrimaryKey.Name }} = ?;
+WHERE {{ .Key.Placeholders }};
 
 -- 批量删除
 DELETE FROM {{ .TableName }}
-WHERE {{ .PrimaryKey.Name }} IN (?, ?, ?);
+{{ if not .Key.IsComposite }}WHERE {{ .PrimaryKey.Name }} IN (?, ?, ?);{{ else if .UseTupleIn }}WHERE ({{ .Key.Columns }}) IN ({{ range $i := seq 3 }}{{ if $i }}, {{ end }}({{ range $j, $field := $.Key.Fields }}{{ if $j }}, {{ end }}?{{ end }}){{ end }});{{ else }}WHERE {{ range $i := seq 3 }}{{ if $i }}
+   OR {{ end }}({{ $.Key.Placeholders }}){{ end }};{{ end }}
 {{ end }}
+{{- end }}
 
 -- 分页查询
 SELECT {{ range $i, $field := .Fields }}{{ if $i }}, {{ end }}{{ $field.Name }}{{ end }}
 FROM {{ .TableName }}
-ORDER BY {{ if .HasPrimaryKey }}{{ .PrimaryKey.Name }}{{ else }}{{ (index .Fields 0).Name }}{{ end }}
+ORDER BY {{ .OrderBy }}
 LIMIT ? OFFSET ?;
 
 -- 统计总数
@@ -154,7 +189,7 @@ SELECT COUNT(*) FROM {{ .TableName }};
 {{ if .HasPrimaryKey }}
 -- 检查记录是否存在
 SELECT COUNT(*) FROM {{ .TableName }}
-WHERE {{ .PrimaryKey.Name }} = ?;
+WHERE {{ .Key.Placeholders }};
 {{ end }}
 
 -- 条件查询示例
@@ -162,14 +197,14 @@ SELECT {{ range $i, $field := .Fields }}{{ if $i }}, {{ end }}{{ $field.Name }}{
 FROM {{ .TableName }}
 WHERE 1=1
 {{ range .Fields }}{{ if not .IsPrimaryKey }}  -- AND {{ .Name }} = ?
-{{ end }}{{ end }}ORDER BY {{ if .HasPrimaryKey }}{{ .PrimaryKey.Name }}{{ else }}{{ (index .Fields 0).Name }}{{ end }};
+{{ end }}{{ end }}ORDER BY {{ .OrderBy }};
 
 -- 模糊查询示例
 SELECT {{ range $i, $field := .Fields }}{{ if $i }}, {{ end }}{{ $field.Name }}{{ end }}
 FROM {{ .TableName }}
 WHERE 1=1
 {{ range .Fields }}{{ if or (contains .Type "varchar") (contains .Type "text") (contains .Type "char") }}  -- AND {{ .Name }} LIKE CONCAT('%', ?, '%')
-{{ end }}{{ end }}ORDER BY {{ if .HasPrimaryKey }}{{ .PrimaryKey.Name }}{{ else }}{{ (index .Fields 0).Name }}{{ end }};
+{{ end }}{{ end }}ORDER BY {{ .OrderBy }};
 
 -- 范围查询示例
 SELECT {{ range $i, $field := .Fields }}{{ if $i }}, {{ end }}{{ $field.Name }}{{ end }}
@@ -177,7 +212,7 @@ FROM {{ .TableName }}
 WHERE 1=1
 {{ range .Fields }}{{ if or (contains .Type "int") (contains .Type "decimal") (contains .Type "float") (contains .Type "double") }}  -- AND {{ .Name }} BETWEEN ? AND ?
 {{ end }}{{ end }}{{ range .Fields }}{{ if or (contains .Type "date") (contains .Type "time") }}  -- AND {{ .Name }} BETWEEN ? AND ?
-{{ end }}{{ end }}ORDER BY {{ if .HasPrimaryKey }}{{ .PrimaryKey.Name }}{{ else }}{{ (index .Fields 0).Name }}{{ end }};
+{{ end }}{{ end }}ORDER BY {{ .OrderBy }};
 `
 	
 	// 添加模板函数
@@ -14,6 +14,7 @@ import (
 // StructGenerator 结构体生成器
 type StructGenerator struct {
 	config *config.Config
+	tables []database.Table
 }
 
 // NewStructGenerator 创建结构体生成器
@@ -21,6 +22,11 @@ func NewStructGenerator(cfg *config.Config) *StructGenerator {
 	return &StructGenerator{config: cfg}
 }
 
+// SetTables 设置参与生成的表，用于解析外键关联
+func (sg *StructGenerator) SetTables(tables []database.Table) {
+	sg.tables = tables
+}
+
 // StructData 结构体模板数据
 type StructData struct {
 	Package     string
@@ -28,8 +34,9 @@ type StructData struct {
 	TableName   string
 	Comment     string
 	Fields      []FieldData
-	HasTimeType bool
-	HasJSONType bool
+	PrimaryKey  PrimaryKeyData
+	Relations   []RelationData
+	Imports     []string
 }
 
 // FieldData 字段模板数据
@@ -37,9 +44,16 @@ type FieldData struct {
 	Name         string
 	Type         string
 	ColumnName   string
+	Import       string // 类型需要的导入路径，由类型映射配置指定
 	JSONTag      string
 	Comment      string
 	IsPrimaryKey bool
+	IsAutoIncr   bool
+	Nullable     bool  // 列可为 NULL
+	IsGenerated  bool  // 生成列，由数据库计算，不参与插入和更新
+	Length       int64 // 字符和二进制类型的最大长度，未知时为 0
+	Precision    int   // 定点数和浮点数的精度，未知时为 0
+	Scale        int   // 定点数和浮点数的小数位数
 }
 
 // Generate 生成结构体代码
@@ -65,7 +79,7 @@ func (sg *StructGenerator) Generate(table database.Table) error {
 		return fmt.Errorf("写入文件失败: %w", err)
 	}
 	
-	fmt.Printf("  生成结构体文件: %s\n", filepath)
+	fmt.Printf("  生成结构体文件: %s\n", outputPath(sg.config, filepath))
 	return nil
 }
 
@@ -73,8 +87,8 @@ func (sg *StructGenerator) Generate(table database.Table) error {
 func (sg *StructGenerator) prepareTemplateData(table database.Table) StructData {
 	data := StructData{
 		Package:    "model",
-		StructName: toPascalCase(removeTablePrefix(table.Name, sg.config.Tables.Prefix)),
-		TableName:  table.Name,
+		StructName: structName(table, sg.config),
+		TableName:  table.QualifiedName(),
 		Comment:    table.Comment,
 	}
 	
@@ -82,10 +96,17 @@ func (sg *StructGenerator) prepareTemplateData(table database.Table) StructData
 	for _, col := range table.Columns {
 		field := FieldData{
 			Name:         toPascalCase(col.Name),
-			Type:         col.GoType,
+			Type:         fieldGoType(table, col, sg.config),
 			ColumnName:   col.Name,
+			Import:       col.GoImport,
 			Comment:      col.Comment,
 			IsPrimaryKey: col.IsPrimaryKey,
+			IsAutoIncr:   col.IsAutoIncr,
+			Nullable:     col.Nullable,
+			IsGenerated:  col.Generated != "",
+			Length:       col.Length,
+			Precision:    col.Precision,
+			Scale:        col.Scale,
 		}
 		
 		// 生成标签
@@ -106,16 +127,25 @@ func (sg *StructGenerator) prepareTemplateData(table database.Table) StructData
 
 		
 		// 检查是否需要导入特殊包
-		if strings.Contains(col.GoType, "time.Time") {
-			data.HasTimeType = true
-		}
-		if strings.Contains(col.GoType, "json.RawMessage") {
-			data.HasJSONType = true
-		}
+		data.Imports = appendFieldImports(data.Imports, field)
 		
 		data.Fields = append(data.Fields, field)
 	}
 	
+	data.PrimaryKey = newPrimaryKeyData(data.StructName, data.Fields)
+	
+	// 处理外键关联字段，关联字段不参与数据库列映射
+	if sg.config.Options.GenerateRelations {
+		data.Relations = buildRelations(table, sg.tables, sg.config)
+		for i, relation := range data.Relations {
+			tag := `db:"-"`
+			if sg.config.Options.JSONTag {
+				tag += fmt.Sprintf(` json:"%s,omitempty"`, toSnakeCase(relation.Property))
+			}
+			data.Relations[i].Tag = tag
+		}
+	}
+	
 	return data
 }
 
@@ -125,9 +155,8 @@ func (sg *StructGenerator) prepareTemplateData(table database.Table) StructData
 func (sg *StructGenerator) generateCode(data StructData) (string, error) {
 	tmpl := `package {{ .Package }}
 
-{{ if or .HasTimeType .HasJSONType }}import ({{ if .HasTimeType }}
-	"time"{{ end }}{{ if .HasJSONType }}
-	"encoding/json"{{ end }}
+{{ if .Imports }}import ({{ range .Imports }}
+	"{{ . }}"{{ end }}
 ){{ end }}
 
 // {{ .StructName }} {{ .Comment }}
@@ -135,13 +164,32 @@ type {{ .StructName }} struct {
 {{- range .Fields }}
 	{{ .Name }} {{ .Type }} ` + "`" + `{{ if .JSONTag }}{{ .JSONTag }}{{ end }}` + "`" + `{{ if .Comment }} // {{ .Comment }}{{ end }}
 {{- end }}
+{{- range .Relations }}
+	{{ .Property }} {{ .FieldType }} ` + "`" + `{{ .Tag }}` + "`" + ` // 关联 {{ .TableName }}（外键 {{ .ForeignKey }}）
+{{- end }}
 }
 
 // TableName 返回表名
 func ({{ .StructName }}) TableName() string {
 	return "{{ .TableName }}"
 }
-`
+{{ if .PrimaryKey.IsComposite }}
+// {{ .PrimaryKey.StructName }} {{ .StructName }} 复合主键
+type {{ .PrimaryKey.StructName }} struct {
+{{- range .PrimaryKey.Fields }}
+	{{ .Name }} {{ .Type }} ` + "`" + `{{ if .JSONTag }}{{ .JSONTag }}{{ end }}` + "`" + `
+{{- end }}
+}
+
+// Key 返回{{ .StructName }}的复合主键
+func (m *{{ .StructName }}) Key() {{ .PrimaryKey.StructName }} {
+	return {{ .PrimaryKey.StructName }}{
+{{- range .PrimaryKey.Fields }}
+		{{ .Name }}: m.{{ .Name }},
+{{- end }}
+	}
+}
+{{ end }}`
 	
 	t, err := template.New("struct").Parse(tmpl)
 	if err != nil {
@@ -171,6 +219,15 @@ func toPascalCase(s string) string {
 	return strings.Join(words, "")
 }
 
+// toCamelCase 转换为 camelCase，用于方法参数名
+func toCamelCase(s string) string {
+	pascal := toPascalCase(s)
+	if pascal == "" {
+		return pascal
+	}
+	return strings.ToLower(pascal[:1]) + pascal[1:]
+}
+
 // toSnakeCase 转换为 snake_case
 func toSnakeCase(s string) string {
 	var result strings.Builder
@@ -183,6 +240,17 @@ func toSnakeCase(s string) string {
 	return strings.ToLower(result.String())
 }
 
+// structName 返回表对应的结构体名。多个 schema 生成到同一个包时，
+// 除第一个 schema 外的表名前加 schema 名，避免不同 schema 的同名表冲突
+func structName(table database.Table, cfg *config.Config) string {
+	name := toPascalCase(removeTablePrefix(table.Name, cfg.Tables.Prefix))
+	schemas := cfg.Database.Schemas
+	if table.Schema != "" && !cfg.Output.SplitSchemas && len(schemas) > 1 && table.Schema != schemas[0] {
+		name = toPascalCase(table.Schema) + name
+	}
+	return name
+}
+
 // removeTablePrefix 移除表前缀
 func removeTablePrefix(tableName, prefix string) string {
 	if prefix != "" && strings.HasPrefix(tableName, prefix) {
@@ -0,0 +1,68 @@
+package generator
+
+import (
+	"strings"
+	
+	"go-mapper-gen/internal/config"
+	"go-mapper-gen/internal/database"
+)
+
+// applyTypeMappings 按类型映射配置和 null_style 计算列的 Go 类型，返回修改后的副本。
+// 列映射优先于全局映射，同一列表中先声明的映射优先；被覆盖的枚举列不再生成枚举类型。
+// 映射显式指定 nullable 类型时可空列直接使用该类型，否则按 null_style 包装。
+// 数据源没有设置 Go 类型的列由驱动的 GoType 转换
+func applyTypeMappings(tables []database.Table, cfg *config.Config) []database.Table {
+	dialect := cfg.Database.SQLDialect()
+	style := cfg.Options.NullStyle
+	factory, hasFactory := database.Lookup(cfg.Database.Driver)
+	mapped := make([]database.Table, len(tables))
+	for i, table := range tables {
+		columns := make([]database.Column, len(table.Columns))
+		for j, col := range table.Columns {
+			if col.GoType == "" && hasFactory {
+				dbType := col.FullType
+				if dbType == "" {
+					dbType = col.Type
+				}
+				col.GoType = factory.GoType(dbType, col.Nullable)
+			}
+			wrap := col.Nullable && strings.HasPrefix(col.GoType, "*")
+			if goType, importPath, explicit, ok := mappedGoType(cfg.Types, dialect, table, col); ok {
+				col.GoType = goType
+				col.GoImport = importPath
+				col.EnumValues = nil
+				col.EnumName = ""
+				wrap = col.Nullable && !explicit
+			}
+			// interface{}、数组等本身可以表示 NULL 的类型保持不变
+			if wrap {
+				col.GoType = nullableGoType(strings.TrimPrefix(col.GoType, "*"), style)
+			}
+			columns[j] = col
+		}
+		table.Columns = columns
+		mapped[i] = table
+	}
+	return mapped
+}
+
+// mappedGoType 查找适用于列的类型映射，返回不可空的类型，映射显式指定 nullable 类型时返回该类型
+func mappedGoType(types config.TypesConfig, dialect string, table database.Table, col database.Column) (string, string, bool, bool) {
+	for _, mapping := range types.Columns {
+		if (mapping.MatchTable(table.Name) || mapping.MatchTable(table.QualifiedName())) && mapping.Column == col.Name {
+			explicit := col.Nullable && mapping.Nullable != ""
+			goType, importPath := mapping.GoTypeFor(explicit)
+			return goType, importPath, explicit, true
+		}
+	}
+	
+	// 完整类型 (tinyint(1)、int4[])、数据类型或 PostgreSQL 枚举类型名相同即匹配
+	for _, mapping := range types.Mappings {
+		if mapping.MatchType(dialect, col.FullType, col.Type, col.EnumName) {
+			explicit := col.Nullable && mapping.Nullable != ""
+			goType, importPath := mapping.GoTypeFor(explicit)
+			return goType, importPath, explicit, true
+		}
+	}
+	return "", "", false, false
+}
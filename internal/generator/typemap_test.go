@@ -0,0 +1,78 @@
+package generator
+
+import (
+	"testing"
+
+	"go-mapper-gen/internal/config"
+	"go-mapper-gen/internal/database"
+)
+
+func TestApplyTypeMappings(t *testing.T) {
+	cfg := &config.Config{
+		Database: config.DatabaseConfig{Driver: "mysql"},
+		Types: config.TypesConfig{
+			Mappings: []config.TypeMapping{
+				{Driver: "postgres", DBType: "decimal", GoType: "float32"},
+				{DBType: "decimal", GoType: "github.com/shopspring/decimal.Decimal", Nullable: "github.com/shopspring/decimal.NullDecimal"},
+				{DBType: "tinyint(1)", GoType: "int8"},
+			},
+			Columns: []config.ColumnTypeMapping{
+				{Table: "orders", Column: "price", GoType: "int64"},
+				{Column: "status", GoType: "string"},
+			},
+		},
+	}
+	tables := []database.Table{
+		{Name: "orders", Columns: []database.Column{
+			{Name: "price", Type: "decimal", GoType: "float64"},
+			{Name: "total", Type: "decimal", GoType: "*float64", Nullable: true},
+			{Name: "paid", Type: "tinyint", FullType: "tinyint(1)", GoType: "bool"},
+			{Name: "status", Type: "enum", GoType: "string", EnumValues: []string{"a"}},
+		}},
+	}
+	
+	mapped := applyTypeMappings(tables, cfg)
+	expected := []struct {
+		goType   string
+		goImport string
+	}{
+		{"int64", ""},
+		{"decimal.NullDecimal", "github.com/shopspring/decimal"},
+		{"int8", ""},
+		{"string", ""},
+	}
+	for i, col := range mapped[0].Columns {
+		if col.GoType != expected[i].goType || col.GoImport != expected[i].goImport {
+			t.Errorf("列 %s: 期望 %s (%s)，实际为 %s (%s)", col.Name, expected[i].goType, expected[i].goImport, col.GoType, col.GoImport)
+		}
+	}
+	if mapped[0].Columns[3].EnumValues != nil {
+		t.Error("期望被覆盖的枚举列不再生成枚举类型")
+	}
+	if tables[0].Columns[0].GoType != "float64" {
+		t.Error("期望不修改原始表结构")
+	}
+}
+
+func TestApplyTypeMappingsDriverGoType(t *testing.T) {
+	cfg := &config.Config{
+		Database: config.DatabaseConfig{Driver: "mysql"},
+		Options:  config.OptionsConfig{NullStyle: "sql_null"},
+	}
+	tables := []database.Table{
+		{Name: "users", Columns: []database.Column{
+			{Name: "id", Type: "bigint", FullType: "bigint unsigned"},
+			{Name: "name", Type: "varchar", Nullable: true},
+			{Name: "age", Type: "int", GoType: "int64"},
+		}},
+	}
+	
+	// 数据源没有设置 Go 类型时使用驱动的转换结果，再按 null_style 包装
+	expected := []string{"uint64", "sql.NullString", "int64"}
+	mapped := applyTypeMappings(tables, cfg)
+	for i, col := range mapped[0].Columns {
+		if col.GoType != expected[i] {
+			t.Errorf("列 %s: 期望 %s，实际为 %s", col.Name, expected[i], col.GoType)
+		}
+	}
+}
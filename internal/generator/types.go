@@ -0,0 +1,204 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+
+	"go-mapper-gen/internal/config"
+	"go-mapper-gen/internal/database"
+)
+
+// TypesGenerator 生成模型包共用的类型，包括枚举类型和数据库专用类型
+type TypesGenerator struct {
+	config *config.Config
+}
+
+// NewTypesGenerator 创建类型生成器
+func NewTypesGenerator(cfg *config.Config) *TypesGenerator {
+	return &TypesGenerator{config: cfg}
+}
+
+// TypesData 类型文件模板数据
+type TypesData struct {
+	Package string
+	Imports []string
+	Enums   []EnumData
+	Helpers []string // 辅助类型代码
+}
+
+// typesFileName 类型文件名，使用生成文件的命名约定，避免与名为 types 的表生成的结构体文件冲突
+const typesFileName = "zz_generated_types.go"
+
+// Generate 生成 model/zz_generated_types.go，没有需要生成的类型时不写入文件
+func (tg *TypesGenerator) Generate(tables []database.Table) error {
+	data := TypesData{
+		Package: "model",
+		Enums:   buildEnums(tables, tg.config),
+	}
+	
+	// 枚举类型需要 Scanner/Valuer，SET 类型校验时需要拆分逗号分隔的值
+	imports := make(map[string]bool)
+	for _, enum := range data.Enums {
+		imports["database/sql/driver"] = true
+		imports["fmt"] = true
+		if enum.IsSet {
+			imports["strings"] = true
+		}
+	}
+	for _, name := range usedHelperTypes(tables) {
+		helper := helperTypes[name]
+		for _, path := range helper.imports {
+			imports[path] = true
+		}
+		data.Helpers = append(data.Helpers, helper.code)
+	}
+	if len(data.Enums) == 0 && len(data.Helpers) == 0 {
+		return nil
+	}
+	for path := range imports {
+		data.Imports = append(data.Imports, path)
+	}
+	sort.Strings(data.Imports)
+	
+	code, err := tg.generateCode(data)
+	if err != nil {
+		return fmt.Errorf("生成代码失败: %w", err)
+	}
+	
+	modelDir := filepath.Join(tg.config.Output.Dir, "model")
+	if err := os.MkdirAll(modelDir, 0755); err != nil {
+		return fmt.Errorf("创建model目录失败: %w", err)
+	}
+	filename := filepath.Join(modelDir, typesFileName)
+	if err := os.WriteFile(filename, []byte(code), 0644); err != nil {
+		return fmt.Errorf("写入文件失败: %w", err)
+	}
+	
+	fmt.Printf("  生成类型文件: %s\n", outputPath(tg.config, filename))
+	return nil
+}
+
+// generateCode 生成代码
+func (tg *TypesGenerator) generateCode(data TypesData) (string, error) {
+	tmpl := `package {{ .Package }}
+
+import ({{ range .Imports }}
+	"{{ . }}"{{ end }}
+)
+{{- range .Enums }}
+{{- $type := .TypeName }}
+
+// {{ $type }} {{ .Source }} 的{{ if .IsSet }}集合类型，值为逗号分隔的多个可选值{{ else }}枚举类型{{ end }}
+type {{ $type }} string
+
+// {{ $type }} 的可选值
+const (
+{{- range .Constants }}
+	{{ .Name }} {{ $type }} = {{ .Value }}
+{{- end }}
+)
+
+// IsValid 判断是否为合法的{{ if .IsSet }}可选值组合{{ else }}可选值{{ end }}
+func (e {{ $type }}) IsValid() bool {
+{{- if .IsSet }}
+	if e == "" {
+		return true
+	}
+	for _, v := range strings.Split(string(e), ",") {
+		switch {{ $type }}(v) {
+		case {{ range $i, $c := .Constants }}{{ if $i }}, {{ end }}{{ $c.Name }}{{ end }}:
+		default:
+			return false
+		}
+	}
+	return true
+{{- else }}
+	switch e {
+	case {{ range $i, $c := .Constants }}{{ if $i }}, {{ end }}{{ $c.Name }}{{ end }}:
+		return true
+	}
+	return false
+{{- end }}
+}
+
+// String 实现 fmt.Stringer 接口
+func (e {{ $type }}) String() string {
+	return string(e)
+}
+
+// Scan 实现 sql.Scanner 接口
+func (e *{{ $type }}) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*e = ""
+	case string:
+		*e = {{ $type }}(v)
+	case []byte:
+		*e = {{ $type }}(v)
+	default:
+		return fmt.Errorf("无法将 %T 转换为 {{ $type }}", src)
+	}
+	return nil
+}
+
+// Value 实现 driver.Valuer 接口，写入前校验可选值{{ if .ZeroNull }}，空字符串写入为 NULL{{ end }}
+func (e {{ $type }}) Value() (driver.Value, error) {
+{{- if .ZeroNull }}
+	if e == "" {
+		return nil, nil
+	}
+{{- end }}
+	if !e.IsValid() {
+		return nil, fmt.Errorf("{{ $type }} 的值 %q 不合法", string(e))
+	}
+	return string(e), nil
+}
+{{- end }}
+
+{{- range .Helpers }}
+
+{{ . }}
+{{- end }}
+`
+
+	t, err := template.New("types").Parse(tmpl)
+	if err != nil {
+		return "", fmt.Errorf("解析模板失败: %w", err)
+	}
+	
+	var buf strings.Builder
+	if err := t.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("执行模板失败: %w", err)
+	}
+	
+	return buf.String(), nil
+}
+
+// fieldGoType 返回列在模型结构体中的 Go 类型，ENUM/SET 列使用生成的枚举类型，可空时按 null_style 包装
+func fieldGoType(table database.Table, col database.Column, cfg *config.Config) string {
+	if len(col.EnumValues) == 0 {
+		return col.GoType
+	}
+	typeName := enumTypeName(table, col, cfg)
+	if col.Nullable {
+		return nullableGoType(typeName, cfg.Options.NullStyle)
+	}
+	return typeName
+}
+
+// qualifyModelType 为模型包中定义的类型添加 model 包名，用于 DAO 等其他包引用，
+// 泛型类型的类型参数同样处理，如 sql.Null[UsersStatus] 转换为 sql.Null[model.UsersStatus]
+func qualifyModelType(goType string) string {
+	if i := strings.Index(goType, "["); i > 0 && strings.HasSuffix(goType, "]") {
+		return goType[:i+1] + qualifyModelType(goType[i+1:len(goType)-1]) + "]"
+	}
+	base := strings.TrimLeft(goType, "*[]")
+	if base == "" || strings.Contains(base, ".") || base[0] < 'A' || base[0] > 'Z' {
+		return goType
+	}
+	return goType[:len(goType)-len(base)] + "model." + base
+}